@@ -0,0 +1,147 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"vleet/internal/config"
+)
+
+func TestCLI_Submit_Interrupted_RecordsPendingAndExits130(t *testing.T) {
+	dir := t.TempDir()
+
+	cfgPath := filepath.Join(dir, "config.yaml")
+	store := config.NewFileStore(cfgPath)
+	if err := store.Save(context.Background(), config.Config{
+		Editor:      "true",
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret", CSRFTOKEN: "csrf-secret"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	wsDir := filepath.Join(dir, "two-sum")
+	if err := os.MkdirAll(wsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wsDir, "solution.cpp"), []byte("CODE\n"), 0o644); err != nil {
+		t.Fatalf("write solution: %v", err)
+	}
+
+	var once sync.Once
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/graphql":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"question": map[string]any{
+						"questionId":   "1",
+						"titleSlug":    "two-sum",
+						"codeSnippets": []map[string]any{},
+					},
+				},
+			})
+		case "/problems/two-sum/submit/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"submission_id": 123})
+		case "/submissions/detail/123/check/":
+			// The judge is still busy; the user hits Ctrl-C while vleet waits.
+			_ = json.NewEncoder(w).Encode(map[string]any{"state": "PENDING"})
+			once.Do(func() { _ = syscall.Kill(os.Getpid(), syscall.SIGINT) })
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+
+	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "submit", "two-sum", "--lang", "cpp"})
+	if code != kExitInterrupted {
+		t.Fatalf("exit=%d, want %d\nstdout:\n%s\nstderr:\n%s", code, kExitInterrupted, stdout, stderr)
+	}
+	if !strings.Contains(stderr, "vleet status --problem two-sum 123") {
+		t.Fatalf("expected resume hint; stderr:\n%s", stderr)
+	}
+
+	b, err := os.ReadFile(filepath.Join(wsDir, ".vleet", "history.jsonl"))
+	if err != nil {
+		t.Fatalf("read history: %v", err)
+	}
+	if !strings.Contains(string(b), `"submission_id":123`) || !strings.Contains(string(b), `"state":"PENDING"`) {
+		t.Fatalf("expected pending record in history, got:\n%s", b)
+	}
+}
+
+func TestCLI_Solve_CtrlCInsideEditor_StillSubmits(t *testing.T) {
+	dir := t.TempDir()
+
+	// The "editor" gets Ctrl-C the way a terminal delivers it: vleet receives SIGINT too.
+	editorPath := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(editorPath, []byte("#!/bin/sh\nkill -INT $PPID\nsleep 0.3\n"), 0o755); err != nil {
+		t.Fatalf("write editor: %v", err)
+	}
+
+	cfgPath := filepath.Join(dir, "config.yaml")
+	store := config.NewFileStore(cfgPath)
+	if err := store.Save(context.Background(), config.Config{
+		Editor:      editorPath,
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret", CSRFTOKEN: "csrf-secret"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var mu sync.Mutex
+	submitted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/graphql":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"question": map[string]any{
+						"questionId":   "1",
+						"titleSlug":    "two-sum",
+						"codeSnippets": []map[string]any{{"langSlug": "cpp", "code": "class Solution {};"}},
+					},
+				},
+			})
+		case "/problems/two-sum/submit/":
+			mu.Lock()
+			submitted = true
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"submission_id": 123})
+		case "/submissions/detail/123/check/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"state": "SUCCESS", "status_msg": "Accepted"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+	t.Setenv("NVIM", "")
+
+	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "solve", "--lang", "cpp", "--submit", "two-sum"})
+	if code != 0 {
+		t.Fatalf("exit=%d, want 0\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !submitted || !strings.Contains(stdout, "Verdict: Accepted") {
+		t.Fatalf("submitted=%v; stdout:\n%s", submitted, stdout)
+	}
+}
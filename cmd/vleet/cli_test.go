@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"vleet/internal/config"
+	"vleet/internal/errx"
 )
 
 func TestCLI_ConfigShow_RedactsSecrets(t *testing.T) {
@@ -32,6 +33,7 @@ func TestCLI_ConfigShow_RedactsSecrets(t *testing.T) {
 			Session:   "sess-secret",
 			CSRFTOKEN: "csrf-secret",
 		},
+		Serve: config.ServeConfig{Token: "serve-secret"},
 	}); err != nil {
 		t.Fatalf("save config: %v", err)
 	}
@@ -57,6 +59,9 @@ func TestCLI_ConfigShow_RedactsSecrets(t *testing.T) {
 	if !strings.Contains(stdout, "leetcode.csrftoken: (set)") {
 		t.Fatalf("expected csrftoken status to be shown as set; stdout:\n%s", stdout)
 	}
+	if strings.Contains(stdout, "serve-secret") || !strings.Contains(stdout, "serve.token: (set)") {
+		t.Fatalf("expected serve token to be redacted; stdout:\n%s", stdout)
+	}
 }
 
 func TestCLI_Fetch_Smoke_CreatesSolutionFile(t *testing.T) {
@@ -268,8 +273,9 @@ func TestCLI_Submit_Error_DoesNotLeakSecrets(t *testing.T) {
 	t.Setenv(kEnvVleetConfigPath, cfgPath)
 
 	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "submit", "two-sum", "--lang", "cpp"})
-	if code != 1 {
-		t.Fatalf("exit=%d (want 1)\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	// LeetCode answers 403 to expired sessions: an auth error.
+	if code != errx.ExitAuth {
+		t.Fatalf("exit=%d (want %d)\nstdout:\n%s\nstderr:\n%s", code, errx.ExitAuth, stdout, stderr)
 	}
 	if strings.Contains(stdout, "sess-secret") || strings.Contains(stderr, "sess-secret") {
 		t.Fatalf("session secret leaked in output")
@@ -339,3 +345,326 @@ func runRealMainCaptured(t *testing.T, dir string, args []string) (code int, std
 
 	return code, stdout, stderr
 }
+
+func TestCLI_Submissions_JSON_ListsRemoteSubmissions(t *testing.T) {
+	dir := t.TempDir()
+
+	cfgPath := filepath.Join(dir, "config.yaml")
+	store := config.NewFileStore(cfgPath)
+	if err := store.Save(context.Background(), config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"questionSubmissionList": map[string]any{
+					"hasNext": false,
+					"submissions": []map[string]any{
+						{"id": "42", "statusDisplay": "Wrong Answer", "lang": "python3", "runtime": "N/A", "memory": "N/A", "timestamp": "1700000000"},
+					},
+				},
+			},
+		})
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+
+	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "submissions", "--json", "two-sum"})
+	if code != 0 {
+		t.Fatalf("exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+
+	var env struct {
+		Schema string           `json:"schema"`
+		Kind   string           `json:"kind"`
+		Data   []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &env); err != nil {
+		t.Fatalf("stdout is not a JSON envelope: %v\n%s", err, stdout)
+	}
+	if env.Schema != "vleet/v1" || env.Kind != "submissions" {
+		t.Fatalf("envelope = %s/%s, want vleet/v1/submissions", env.Schema, env.Kind)
+	}
+	got := env.Data
+	if len(got) != 1 || got[0]["id"] != float64(42) || got[0]["status"] != "Wrong Answer" || got[0]["lang"] != "python3" {
+		t.Fatalf("submissions = %v", got)
+	}
+}
+
+func TestCLI_Pull_WritesAcceptedCode_RespectsNoOverwrite(t *testing.T) {
+	dir := t.TempDir()
+
+	cfgPath := filepath.Join(dir, "config.yaml")
+	store := config.NewFileStore(cfgPath)
+	if err := store.Save(context.Background(), config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		var data map[string]any
+		switch {
+		case strings.Contains(body.Query, "questionSubmissionList"):
+			data = map[string]any{"questionSubmissionList": map[string]any{
+				"hasNext": false,
+				"submissions": []map[string]any{
+					{"id": "3", "statusDisplay": "Wrong Answer", "lang": "cpp", "timestamp": "3"},
+					{"id": "2", "statusDisplay": "Accepted", "lang": "python3", "timestamp": "2"},
+					{"id": "1", "statusDisplay": "Accepted", "lang": "cpp", "timestamp": "1"},
+				},
+			}}
+		case strings.Contains(body.Query, "submissionDetails"):
+			data = map[string]any{"submissionDetails": map[string]any{
+				"code":     "class Solution { /* accepted */ };",
+				"lang":     map[string]any{"name": "cpp"},
+				"question": map[string]any{"titleSlug": "two-sum"},
+			}}
+		default:
+			data = map[string]any{"question": map[string]any{
+				"questionId":   "1",
+				"title":        "Two Sum",
+				"titleSlug":    "two-sum",
+				"difficulty":   "Easy",
+				"codeSnippets": []map[string]any{},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+
+	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "pull", "--lang", "cpp", "two-sum"})
+	if code != 0 {
+		t.Fatalf("exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	solPath := filepath.Join(dir, "two-sum", "solution.cpp")
+	b, err := os.ReadFile(solPath)
+	if err != nil {
+		t.Fatalf("read solution: %v", err)
+	}
+	if !strings.HasPrefix(string(b), "//") || !strings.Contains(string(b), "Two Sum") || !strings.Contains(string(b), "/* accepted */") {
+		t.Fatalf("expected rendered header + accepted code, got:\n%s", b)
+	}
+
+	if err := os.WriteFile(solPath, []byte("LOCAL EDITS\n"), 0o644); err != nil {
+		t.Fatalf("write solution: %v", err)
+	}
+	code, _, stderr = runRealMainCaptured(t, dir, []string{"vleet", "pull", "--lang", "cpp", "two-sum"})
+	if code == 0 || !strings.Contains(stderr, "--force") {
+		t.Fatalf("expected pull to refuse overwrite; exit=%d stderr:\n%s", code, stderr)
+	}
+	if b, _ := os.ReadFile(solPath); string(b) != "LOCAL EDITS\n" {
+		t.Fatalf("solution was overwritten without --force:\n%s", b)
+	}
+
+	code, _, stderr = runRealMainCaptured(t, dir, []string{"vleet", "pull", "--lang", "cpp", "--force", "two-sum"})
+	if code != 0 {
+		t.Fatalf("exit=%d stderr:\n%s", code, stderr)
+	}
+	if b, _ := os.ReadFile(solPath); !strings.Contains(string(b), "/* accepted */") {
+		t.Fatalf("expected --force to overwrite, got:\n%s", b)
+	}
+
+	// Without --lang, the newest accepted submission wins whatever its language.
+	code, _, stderr = runRealMainCaptured(t, dir, []string{"vleet", "pull", "two-sum"})
+	if code != 0 {
+		t.Fatalf("exit=%d stderr:\n%s", code, stderr)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "two-sum", "solution.py")); err != nil || !strings.HasPrefix(string(b), "#") {
+		t.Fatalf("expected the python3 submission in solution.py: (%q, %v)", b, err)
+	}
+}
+
+func TestCLI_WorkspaceLayout_FetchThenSubmitFromAnotherDir(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "solutions")
+	elsewhere := filepath.Join(dir, "elsewhere")
+	if err := os.MkdirAll(elsewhere, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := config.NewFileStore(cfgPath).Save(context.Background(), config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret"},
+		Workspace:   config.WorkspaceConfig{Root: root, Layout: "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/graphql":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"question": map[string]any{
+						"questionId":         "1",
+						"questionFrontendId": "1",
+						"title":              "Two Sum",
+						"titleSlug":          "two-sum",
+						"difficulty":         "Easy",
+						"codeSnippets": []map[string]any{
+							{"lang": "C++", "langSlug": "cpp", "code": "class Solution {};"},
+						},
+					},
+				},
+			})
+		case "/problems/two-sum/submit/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"submission_id": 5})
+		case "/submissions/detail/5/check/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"state": "SUCCESS", "status_msg": "Accepted"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+
+	code, stdout, stderr := runRealMainCaptured(t, elsewhere, []string{"vleet", "fetch", "two-sum"})
+	if code != 0 {
+		t.Fatalf("fetch exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(root, "Easy", "1-two-sum", "solution.cpp")); err != nil {
+		t.Fatalf("expected solution under the configured layout: %v", err)
+	}
+
+	code, stdout, stderr = runRealMainCaptured(t, elsewhere, []string{"vleet", "submit", "two-sum"})
+	if code != 0 || !strings.Contains(stdout, "Verdict: Accepted") {
+		t.Fatalf("submit exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+}
+
+func TestCLI_Variant_FetchThenSubmit_RecordsVariant(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := config.NewFileStore(cfgPath).Save(context.Background(), config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess-secret"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var submitted string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/graphql":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"question": map[string]any{
+						"questionId":         "1",
+						"questionFrontendId": "1",
+						"title":              "Two Sum",
+						"titleSlug":          "two-sum",
+						"difficulty":         "Easy",
+						"codeSnippets": []map[string]any{
+							{"lang": "C++", "langSlug": "cpp", "code": "class Solution {};"},
+						},
+					},
+				},
+			})
+		case "/problems/two-sum/submit/":
+			var body struct {
+				TypedCode string `json:"typed_code"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			submitted = body.TypedCode
+			_ = json.NewEncoder(w).Encode(map[string]any{"submission_id": 5})
+		case "/submissions/detail/5/check/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"state": "SUCCESS", "status_msg": "Accepted"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv(kEnvVleetBaseURL, ts.URL)
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+
+	code, stdout, stderr := runRealMainCaptured(t, dir, []string{"vleet", "fetch", "--variant", "dp", "two-sum"})
+	if code != 0 {
+		t.Fatalf("fetch exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	variantPath := filepath.Join(dir, "two-sum", "solution.dp.cpp")
+	if err := os.WriteFile(variantPath, []byte("// dp approach\n"), 0o644); err != nil {
+		t.Fatalf("write variant: %v", err)
+	}
+
+	code, stdout, stderr = runRealMainCaptured(t, dir, []string{"vleet", "submit", "--variant", "dp", "two-sum"})
+	if code != 0 || !strings.Contains(stdout, "Verdict: Accepted") {
+		t.Fatalf("submit exit=%d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if submitted != "// dp approach\n" {
+		t.Fatalf("submitted code = %q, want the dp variant", submitted)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "two-sum", ".vleet", "history.jsonl"))
+	if err != nil || !strings.Contains(string(b), `"variant":"dp"`) {
+		t.Fatalf("history = (%s, %v), want variant dp recorded", b, err)
+	}
+
+	code, _, stderr = runRealMainCaptured(t, dir, []string{"vleet", "submit", "--variant", "dp", "--file", "x.cpp", "two-sum"})
+	if code == 0 || !strings.Contains(stderr, "--variant") {
+		t.Fatalf("expected --file/--variant conflict, exit=%d stderr:\n%s", code, stderr)
+	}
+}
+
+func TestCLI_ExitCodes_UsageAndMissingWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := config.NewFileStore(cfgPath).Save(context.Background(), config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess"},
+	}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	t.Setenv(kEnvVleetConfigPath, cfgPath)
+	// Nothing may reach LeetCode.
+	t.Setenv(kEnvVleetBaseURL, "http://127.0.0.1:1")
+
+	cases := []struct {
+		args     []string
+		wantExit int
+		wantErr  string
+	}{
+		{[]string{"vleet", "submit"}, errx.ExitUsage, "submit: missing <problem-key>"},
+		{[]string{"vleet", "submit", "--nope", "two-sum"}, errx.ExitUsage, "flag provided but not defined"},
+		{[]string{"vleet", "sync", "extra"}, errx.ExitUsage, `sync: unexpected argument "extra"`},
+		{[]string{"vleet", "submit", "two-sum"}, errx.ExitNotFound, "no workspace for two-sum under . (run: vleet fetch two-sum)"},
+		{[]string{"vleet", "run", "two-sum"}, errx.ExitNotFound, "no workspace for two-sum under . (run: vleet fetch two-sum)"},
+	}
+	for _, tc := range cases {
+		code, stdout, stderr := runRealMainCaptured(t, dir, tc.args)
+		if code != tc.wantExit || !strings.Contains(stderr, tc.wantErr) || !strings.Contains(stderr, "hint: ") {
+			t.Errorf("%v: exit=%d (want %d)\nstdout:\n%s\nstderr:\n%s", tc.args[1:], code, tc.wantExit, stdout, stderr)
+		}
+	}
+}
@@ -6,14 +6,30 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/therootusr/go-leetcode"
 	"vleet/internal/app"
 	"vleet/internal/config"
 	"vleet/internal/editor"
 	"vleet/internal/errx"
+	"vleet/internal/format"
+	"vleet/internal/gitx"
+	"vleet/internal/history"
+	"vleet/internal/hooks"
+	"vleet/internal/httpapi"
+	"vleet/internal/lcx"
+	"vleet/internal/mcp"
 	"vleet/internal/output"
 	"vleet/internal/render"
 	"vleet/internal/workspace"
@@ -23,6 +39,11 @@ const (
 	kEnvVleetBaseURL        = "VLEET_BASE_URL"
 	kEnvVleetConfigPath     = "VLEET_CONFIG_PATH"
 	kDefaultLeetCodeBaseURL = "https://leetcode.com"
+
+	// kExitInterrupted follows the shell convention for SIGINT (128 + 2).
+	kExitInterrupted = 130
+
+	kDefaultServeAddr = "127.0.0.1:8787"
 )
 
 func main() {
@@ -38,7 +59,21 @@ func validateArgs(args []string) error {
 	case "solve":
 	case "fetch":
 	case "submit":
+	case "run":
+	case "watch":
+	case "status":
+	case "submissions":
+	case "langs":
+	case "refresh":
+	case "reset":
+	case "fmt":
+	case "pull":
+	case "sync":
+	case "index":
+	case "check-all":
 	case "config":
+	case "mcp":
+	case "serve":
 	case "help", "-h", "--help":
 		break
 	default:
@@ -60,7 +95,10 @@ func realMain(args []string) int {
 		return 0
 	}
 
-	ctx := context.Background()
+	// Ctrl-C / SIGTERM cancel the context so in-flight polling stops cleanly (and pending
+	// submissions are recorded) instead of killing the process mid-request.
+	ctx, interrupts, stop := notifyInterrupts(context.Background())
+	defer stop()
 
 	cfgPath := strings.TrimSpace(os.Getenv(kEnvVleetConfigPath))
 	if cfgPath == "" {
@@ -79,16 +117,21 @@ func realMain(args []string) int {
 		baseURL = kDefaultLeetCodeBaseURL
 	}
 
-	lc := leetcode.NewHttpClient(leetcode.HttpClientOptions{
+	lc := lcx.NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
 		BaseURL:   baseURL,
 		UserAgent: "vleet/0.1.0",
 		Auth:      leetcode.Auth{},
-	})
+	}))
 	ws := workspace.NewFSManager()
+	hist := history.NewFileStore()
 	rend := render.NewHTMLRenderer()
 	ed := editor.NewProcessRunner()
+	ed.HoldInterrupts = interrupts.Hold
 	pr := output.NewStdPrinter(os.Stdout, os.Stderr, false)
 
+	// Hook output goes to stderr so --json stdout stays machine-readable.
+	hookRunner := hooks.NewShellRunner(os.Stderr, os.Stderr)
+
 	a := app.New(app.App{
 		ConfigStore: cfgStore,
 		LeetCode:    lc,
@@ -96,6 +139,10 @@ func realMain(args []string) int {
 		Renderer:    rend,
 		Editor:      ed,
 		Output:      pr,
+		History:     hist,
+		Git:         gitx.NewCLI(),
+		Hooks:       hookRunner,
+		Formatter:   format.NewShellFormatter(),
 	})
 
 	cmd := args[1]
@@ -107,8 +154,36 @@ func realMain(args []string) int {
 		runErr = runFetch(ctx, a, pr, args[2:])
 	case "submit":
 		runErr = runSubmit(ctx, a, pr, args[2:])
+	case "run":
+		runErr = runRun(ctx, a, pr, args[2:])
+	case "watch":
+		runErr = runWatch(ctx, a, pr, args[2:])
+	case "status":
+		runErr = runStatus(ctx, a, pr, args[2:])
+	case "submissions":
+		runErr = runSubmissions(ctx, a, pr, args[2:])
+	case "langs":
+		runErr = runLangs(ctx, a, pr, args[2:])
+	case "refresh":
+		runErr = runRefresh(ctx, a, args[2:])
+	case "reset":
+		runErr = runReset(ctx, a, args[2:])
+	case "fmt":
+		runErr = runFmt(ctx, a, args[2:])
+	case "pull":
+		runErr = runPull(ctx, a, args[2:])
+	case "sync":
+		runErr = runSync(ctx, a, args[2:])
+	case "index":
+		runErr = runIndex(ctx, a, args[2:])
+	case "check-all":
+		runErr = runCheckAll(ctx, a, pr, args[2:])
 	case "config":
 		runErr = runConfig(ctx, cfgStore, pr, args[2:])
+	case "mcp":
+		runErr = runMCP(ctx, a, pr, args[2:])
+	case "serve":
+		runErr = runServe(ctx, a, args[2:])
 	case "help", "-h", "--help":
 		usage(os.Stdout)
 		return 0
@@ -123,10 +198,55 @@ func realMain(args []string) int {
 	}
 
 	_ = pr.PrintError(ctx, runErr)
-	if errors.Is(runErr, errx.ErrNotImplemented) {
-		return 3
+	if errors.Is(runErr, context.Canceled) && ctx.Err() != nil {
+		return kExitInterrupted
+	}
+	return errx.ExitCode(runErr)
+}
+
+// usageErrorf reports an invalid command line (exit code 2).
+func usageErrorf(format string, args ...any) error {
+	return errx.Mark(fmt.Errorf(format, args...), errx.ErrUsage)
+}
+
+// interruptGate cancels a context on SIGINT or SIGTERM. While held, SIGINT is ignored:
+// in a terminal editor Ctrl-C is an editing key, and it reaches vleet too because both
+// share the foreground process group.
+type interruptGate struct {
+	held atomic.Int32
+}
+
+// Hold suspends SIGINT cancellation until the returned func is called.
+func (g *interruptGate) Hold() (release func()) {
+	g.held.Add(1)
+	var once sync.Once
+	return func() { once.Do(func() { g.held.Add(-1) }) }
+}
+
+// notifyInterrupts is signal.NotifyContext for SIGINT and SIGTERM with an interruptGate.
+func notifyInterrupts(parent context.Context) (context.Context, *interruptGate, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	g := &interruptGate{}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				if sig == os.Interrupt && g.held.Load() > 0 {
+					continue
+				}
+				cancel()
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ctx, g, func() {
+		signal.Stop(ch)
+		cancel()
 	}
-	return 1
 }
 
 func runSolve(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
@@ -134,23 +254,38 @@ func runSolve(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []str
 	fs.SetOutput(io.Discard)
 
 	var lang string
+	var file string
+	var variant string
 	var submit bool
 	var asJSON bool
+	var server string
 	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "solution file name/path (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
 	fs.BoolVar(&submit, "submit", false, "submit immediately after editor exits")
 	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+	fs.StringVar(&server, "server", "", "open in a running nvim via its RPC socket (default: $NVIM)")
 
 	if err := fs.Parse(argv); err != nil {
-		return err
+		return errx.Mark(err, errx.ErrUsage)
 	}
 	if fs.NArg() < 1 {
-		return fmt.Errorf("solve: missing <problem-key> (titleSlug)")
+		return usageErrorf("solve: missing <problem-key> (titleSlug)")
 	}
 	pr.JSON = asJSON
 
+	// Inside a running nvim (or with an explicit socket), reuse it instead of nesting editors.
+	if addr := editor.NvimServerAddr(server); addr != "" {
+		r := editor.NewNvimRemoteRunner(addr, a.Editor)
+		r.Explicit = strings.TrimSpace(server) != ""
+		a.Editor = r
+	}
+
 	return a.Solve(ctx, app.SolveOptions{
 		ProblemKey: fs.Arg(0),
 		Lang:       lang,
+		File:       file,
+		Variant:    variant,
 		Submit:     submit,
 	})
 }
@@ -160,21 +295,27 @@ func runFetch(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []str
 	fs.SetOutput(io.Discard)
 
 	var lang string
+	var file string
+	var variant string
 	var asJSON bool
 	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "solution file name/path (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
 	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
 
 	if err := fs.Parse(argv); err != nil {
-		return err
+		return errx.Mark(err, errx.ErrUsage)
 	}
 	if fs.NArg() < 1 {
-		return fmt.Errorf("fetch: missing <problem-key> (titleSlug)")
+		return usageErrorf("fetch: missing <problem-key> (titleSlug)")
 	}
 	pr.JSON = asJSON
 
 	return a.Fetch(ctx, app.FetchOptions{
 		ProblemKey: fs.Arg(0),
 		Lang:       lang,
+		File:       file,
+		Variant:    variant,
 	})
 }
 
@@ -184,29 +325,426 @@ func runSubmit(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []st
 
 	var lang string
 	var file string
+	var variant string
+	var asJSON bool
+	var errorFormat bool
+	var formatCode bool
+	var events bool
+	var failOnReject bool
+	var reports reportFlags
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "path to solution file (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+	fs.BoolVar(&errorFormat, "errorformat", false, "print errors as file:line:col: message (for vim :cfile / :make)")
+	fs.BoolVar(&formatCode, "format", false, "run the configured formatter (format.<lang>) on the code before submitting")
+	fs.BoolVar(&events, "events", false, "stream NDJSON progress events (submitted, polling, result, error)")
+	// Scripts and CI (no terminal on stdin) need the verdict in the exit code.
+	fs.BoolVar(&failOnReject, "fail-on-reject", !isTerminal(os.Stdin), "exit non-zero unless the verdict is Accepted (default when stdin is not a terminal)")
+	fs.Var(&reports, "report", "write a report: junit=<path>, tap=<path>, or junit/tap for stdout (repeatable)")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("submit: missing <problem-key> (titleSlug)")
+	}
+	pr.JSON = asJSON || events
+	pr.ErrorFormat = errorFormat
+	pr.Events = events
+	useReports(a, pr, reports)
+
+	switch {
+	case events:
+		a.Progress = pr.PrintEvent
+	case !pr.JSON && isTerminal(os.Stderr):
+		a.Progress = output.NewSpinner(os.Stderr).Event
+	}
+
+	err := a.Submit(ctx, app.SubmitOptions{
+		ProblemKey:   fs.Arg(0),
+		Lang:         lang,
+		File:         file,
+		Variant:      variant,
+		Format:       formatCode,
+		FailOnReject: failOnReject,
+	})
+	return writeReports(reports, "submit", err)
+}
+
+func runRun(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var file string
+	var variant string
 	var asJSON bool
+	var errorFormat bool
+	var reports reportFlags
 	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
 	fs.StringVar(&file, "file", "", "path to solution file (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
 	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+	fs.BoolVar(&errorFormat, "errorformat", false, "print errors as file:line:col: message (for vim :cfile / :make)")
+	fs.Var(&reports, "report", "write a report: junit=<path>, tap=<path>, or junit/tap for stdout (repeatable)")
 
 	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("run: missing <problem-key> (titleSlug)")
+	}
+	pr.JSON = asJSON
+	pr.ErrorFormat = errorFormat
+	useReports(a, pr, reports)
+
+	err := a.Run(ctx, app.RunOptions{
+		ProblemKey: fs.Arg(0),
+		Lang:       lang,
+		File:       file,
+		Variant:    variant,
+	})
+	return writeReports(reports, "run", err)
+}
+
+// reportFlags collects repeated --report values.
+type reportFlags []*output.Report
+
+func (f *reportFlags) String() string { return "" }
+
+func (f *reportFlags) Set(s string) error {
+	r, err := output.ParseReport(s)
+	if err != nil {
 		return err
 	}
+	for _, prev := range *f {
+		if prev.Path == "" && r.Path == "" {
+			return fmt.Errorf("only one report can go to stdout")
+		}
+	}
+	*f = append(*f, r)
+	return nil
+}
+
+// useReports feeds the reports from a. A report on stdout moves the printer's output to
+// stderr so the report stays parseable.
+func useReports(a *app.App, pr *output.StdPrinter, reports reportFlags) {
+	for _, r := range reports {
+		a.Reports = append(a.Reports, r)
+		if r.Path == "" {
+			pr.Out = os.Stderr
+		}
+	}
+}
+
+// writeReports writes the reports once the command is done, failed or not, and adds
+// any write error to err. The error the command failed with is recorded in each report.
+func writeReports(reports reportFlags, command string, err error) error {
+	for _, r := range reports {
+		r.AddError(command, err)
+		if werr := r.Write(os.Stdout); werr != nil {
+			err = errors.Join(err, werr)
+		}
+	}
+	return err
+}
+
+func runWatch(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var file string
+	var variant string
+	var asJSON bool
+	var debounce time.Duration
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "path to solution file (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+	fs.DurationVar(&debounce, "debounce", 0, "quiet period after a save before running (default 300ms)")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
 	if fs.NArg() < 1 {
-		return fmt.Errorf("submit: missing <problem-key> (titleSlug)")
+		return usageErrorf("watch: missing <problem-key> (titleSlug)")
 	}
 	pr.JSON = asJSON
 
-	return a.Submit(ctx, app.SubmitOptions{
+	return a.Watch(ctx, app.WatchOptions{
+		ProblemKey: fs.Arg(0),
+		Lang:       lang,
+		File:       file,
+		Variant:    variant,
+		Keys:       os.Stdin,
+		Debounce:   debounce,
+	})
+}
+
+func runStatus(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var problemKey string
+	var lang string
+	var asJSON bool
+	fs.StringVar(&problemKey, "problem", "", "record the verdict in this workspace's history (resolves a pending submit)")
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug used to locate the workspace (default: config default_lang)")
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("status: missing <submission-id>")
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil || id <= 0 {
+		return usageErrorf("status: invalid submission id %q", fs.Arg(0))
+	}
+	pr.JSON = asJSON
+
+	return a.Status(ctx, app.StatusOptions{
+		SubmissionID: leetcode.SubmissionID(id),
+		ProblemKey:   problemKey,
+		Lang:         lang,
+	})
+}
+
+func runSubmissions(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("submissions", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var limit int
+	var asJSON bool
+	fs.IntVar(&limit, "limit", 20, "maximum number of submissions to list (newest first)")
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("submissions: missing <problem-key> (titleSlug)")
+	}
+	pr.JSON = asJSON
+
+	return a.Submissions(ctx, app.SubmissionsOptions{
+		ProblemKey: fs.Arg(0),
+		Limit:      limit,
+	})
+}
+
+func runLangs(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("langs", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var asJSON bool
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("langs: missing <problem-key> (titleSlug)")
+	}
+	pr.JSON = asJSON
+
+	return a.Langs(ctx, app.LangsOptions{ProblemKey: fs.Arg(0)})
+}
+
+func runRefresh(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("refresh", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var file string
+	var variant string
+	fs.StringVar(&lang, "lang", "", "only refresh this language's solution (default: every solution file)")
+	fs.StringVar(&file, "file", "", "only refresh this solution file")
+	fs.StringVar(&variant, "variant", "", "only refresh this named variant: solution.<variant>.<ext>")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("refresh: missing <problem-key> (titleSlug)")
+	}
+	return a.Refresh(ctx, app.RefreshOptions{
+		ProblemKey: fs.Arg(0),
+		Lang:       lang,
+		File:       file,
+		Variant:    variant,
+	})
+}
+
+func runReset(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("reset", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var file string
+	var variant string
+	var yes bool
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "path to solution file (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
+	fs.BoolVar(&yes, "yes", false, "do not ask for confirmation")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("reset: missing <problem-key> (titleSlug)")
+	}
+	return a.Reset(ctx, app.ResetOptions{
+		ProblemKey: fs.Arg(0),
+		Lang:       lang,
+		File:       file,
+		Variant:    variant,
+		Yes:        yes,
+		Confirm:    os.Stdin,
+	})
+}
+
+func runFmt(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var file string
+	var variant string
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug (e.g. cpp, python3)")
+	fs.StringVar(&file, "file", "", "path to solution file (overrides default ./<problem-key>/solution.<ext>)")
+	fs.StringVar(&variant, "variant", "", "named solution variant: ./<problem-key>/solution.<variant>.<ext>")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("fmt: missing <problem-key> (titleSlug)")
+	}
+
+	return a.Fmt(ctx, app.FmtOptions{
 		ProblemKey: fs.Arg(0),
 		Lang:       lang,
 		File:       file,
+		Variant:    variant,
 	})
 }
 
+func runPull(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("pull", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var lang string
+	var force bool
+	fs.StringVar(&lang, "lang", "", "LeetCode language slug (default: the newest accepted submission in any language)")
+	fs.BoolVar(&force, "force", false, "overwrite an existing solution file")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("pull: missing <problem-key> (titleSlug)")
+	}
+
+	return a.Pull(ctx, app.PullOptions{
+		ProblemKey: fs.Arg(0),
+		Lang:       lang,
+		Force:      force,
+	})
+}
+
+func runSync(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var dir string
+	var workers int
+	var rate float64
+	var force bool
+	fs.StringVar(&dir, "dir", "", "solutions root directory (default: workspace.root from config, else .)")
+	fs.IntVar(&workers, "workers", 4, "number of problems fetched concurrently")
+	fs.Float64Var(&rate, "rate", 2, "maximum LeetCode requests per second")
+	fs.BoolVar(&force, "force", false, "overwrite solution files not written by sync or edited since")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("sync: unexpected argument %q", fs.Arg(0))
+	}
+
+	return a.Sync(ctx, app.SyncOptions{
+		Root:    dir,
+		Workers: workers,
+		Rate:    rate,
+		Force:   force,
+	})
+}
+
+func runIndex(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("index", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var dir string
+	fs.StringVar(&dir, "dir", "", "solutions root directory (default: workspace.root from config, else .)")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("index: unexpected argument %q", fs.Arg(0))
+	}
+
+	return a.Index(ctx, app.IndexOptions{Root: dir})
+}
+
+func runCheckAll(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("check-all", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var root string
+	var runOnly bool
+	var submit bool
+	var concurrency int
+	var rate float64
+	var asJSON bool
+	var reports reportFlags
+	fs.StringVar(&root, "root", "", "solutions root directory (default: workspace.root from config, else .)")
+	fs.BoolVar(&runOnly, "run-only", false, "only run the examples (the default)")
+	fs.BoolVar(&submit, "submit", false, "re-submit every solution instead of running the examples")
+	fs.IntVar(&concurrency, "concurrency", 2, "number of problems checked concurrently")
+	fs.Float64Var(&rate, "rate", 1, "maximum LeetCode requests per second")
+	fs.BoolVar(&asJSON, "json", false, "emit JSON output")
+	fs.Var(&reports, "report", "write a report: junit=<path>, tap=<path>, or junit/tap for stdout (repeatable)")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("check-all: unexpected argument %q", fs.Arg(0))
+	}
+	if runOnly && submit {
+		return usageErrorf("check-all: use either --run-only or --submit, not both")
+	}
+	pr.JSON = asJSON
+	useReports(a, pr, reports)
+
+	err := a.CheckAll(ctx, app.CheckAllOptions{
+		Root:        root,
+		Submit:      submit,
+		Concurrency: concurrency,
+		Rate:        rate,
+	})
+	return writeReports(reports, "check-all", err)
+}
+
 func runConfig(ctx context.Context, store *config.FileStore, pr *output.StdPrinter, argv []string) error {
 	if len(argv) < 1 {
-		return fmt.Errorf("config: missing subcommand (init|show)")
+		return usageErrorf("config: missing subcommand (init|show)")
 	}
 	switch argv[0] {
 	case "init":
@@ -214,7 +752,7 @@ func runConfig(ctx context.Context, store *config.FileStore, pr *output.StdPrint
 	case "show":
 		return runConfigShow(ctx, store, pr, argv[1:])
 	default:
-		return fmt.Errorf("config: unknown subcommand %q (expected init|show)", argv[0])
+		return usageErrorf("config: unknown subcommand %q (expected init|show)", argv[0])
 	}
 }
 
@@ -230,7 +768,7 @@ func runConfigInit(ctx context.Context, store *config.FileStore, pr *output.StdP
 	fs.BoolVar(&force, "force", false, "overwrite existing config file")
 
 	if err := fs.Parse(argv); err != nil {
-		return err
+		return errx.Mark(err, errx.ErrUsage)
 	}
 
 	if editorCmd == "" {
@@ -271,7 +809,7 @@ func runConfigShow(ctx context.Context, store *config.FileStore, pr *output.StdP
 	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
 	if err := fs.Parse(argv); err != nil {
-		return err
+		return errx.Mark(err, errx.ErrUsage)
 	}
 
 	cfg, err := store.Load(ctx)
@@ -294,11 +832,117 @@ func runConfigShow(ctx context.Context, store *config.FileStore, pr *output.StdP
 	_, _ = fmt.Fprintf(pr.Out, "path: %s\n", store.Path)
 	_, _ = fmt.Fprintf(pr.Out, "editor: %s\n", cfg.Editor)
 	_, _ = fmt.Fprintf(pr.Out, "default_lang: %s\n", cfg.DefaultLang)
+	if cfg.Workspace.Root != "" {
+		_, _ = fmt.Fprintf(pr.Out, "workspace.root: %s\n", cfg.Workspace.Root)
+	}
+	if cfg.Workspace.Layout != "" {
+		_, _ = fmt.Fprintf(pr.Out, "workspace.layout: %s\n", cfg.Workspace.Layout)
+	}
+	if cfg.Git.AutoCommit {
+		_, _ = fmt.Fprintln(pr.Out, "git.autocommit: true")
+	}
+	if cfg.MCP.AllowSubmit {
+		_, _ = fmt.Fprintln(pr.Out, "mcp.allow_submit: true")
+	}
+	if cfg.Serve.Token != "" {
+		_, _ = fmt.Fprintln(pr.Out, "serve.token: (set)")
+	}
+	for _, h := range []struct{ name, cmd string }{
+		{"post_create", cfg.Hooks.PostCreate},
+		{"pre_submit", cfg.Hooks.PreSubmit},
+		{"post_submit", cfg.Hooks.PostSubmit},
+	} {
+		if h.cmd != "" {
+			_, _ = fmt.Fprintf(pr.Out, "hooks.%s: %s\n", h.name, h.cmd)
+		}
+	}
+	langs := make([]string, 0, len(cfg.Format))
+	for lang := range cfg.Format {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		_, _ = fmt.Fprintf(pr.Out, "format.%s: %s\n", lang, cfg.Format[lang])
+	}
 	_, _ = fmt.Fprintf(pr.Out, "leetcode.session: %s\n", sessionStatus)
 	_, _ = fmt.Fprintf(pr.Out, "leetcode.csrftoken: %s\n", csrfStatus)
 	return nil
 }
 
+// runMCP serves MCP over stdin/stdout. stdout carries only JSON-RPC: the printer is put
+// in JSON mode (no human notes) and errors/hook output go to stderr.
+func runMCP(ctx context.Context, a *app.App, pr *output.StdPrinter, argv []string) error {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("mcp: unexpected argument %q", fs.Arg(0))
+	}
+	pr.JSON = true
+
+	return mcp.NewServer(a, "0.1.0").Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// runServe serves the HTTP API until ctx is canceled (Ctrl-C), then drains in-flight
+// requests.
+func runServe(ctx context.Context, a *app.App, argv []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var addr string
+	fs.StringVar(&addr, "addr", kDefaultServeAddr, "listen address (host:port)")
+
+	if err := fs.Parse(argv); err != nil {
+		return errx.Mark(err, errx.ErrUsage)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("serve: unexpected argument %q", fs.Arg(0))
+	}
+
+	cfg, err := a.ConfigStore.Load(ctx)
+	if err != nil {
+		return err
+	}
+	token := strings.TrimSpace(cfg.Serve.Token)
+	if token == "" {
+		return fmt.Errorf("serve.token is not set in config (clients authenticate with it as a bearer token)")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           httpapi.NewServer(a, token).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(os.Stderr, "vleet: serving on http://%s\n", ln.Addr())
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("serve: shutdown: %w", err)
+		}
+		return nil
+	}
+}
+
+// isTerminal reports whether f is a character device (a terminal, not a pipe or file).
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 func usage(w io.Writer) {
 	fmt.Fprintln(w, "vleet - Vim + LeetCode in the terminal")
 	fmt.Fprintln(w)
@@ -306,12 +950,28 @@ func usage(w io.Writer) {
 	fmt.Fprintln(w, "  vleet <command> [args]")
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Commands:")
-	fmt.Fprintln(w, "  solve   <problem-key> --lang <lang> [--submit]")
-	fmt.Fprintln(w, "  fetch   <problem-key> --lang <lang>")
-	fmt.Fprintln(w, "  submit  <problem-key> --lang <lang> [--file <path>]")
+	fmt.Fprintln(w, "  solve   <problem-key> --lang <lang> [--variant <name>] [--submit] [--server <nvim-socket>]")
+	fmt.Fprintln(w, "  fetch   <problem-key> --lang <lang> [--variant <name>]")
+	fmt.Fprintln(w, "  submit  <problem-key> --lang <lang> [--file <path> | --variant <name>] [--format] [--errorformat] [--events] [--fail-on-reject] [--report junit=<path>|tap]")
+	fmt.Fprintln(w, "  run     <problem-key> --lang <lang> [--file <path> | --variant <name>] [--errorformat] [--report junit=<path>|tap]")
+	fmt.Fprintln(w, "  watch   <problem-key> --lang <lang> [--file <path> | --variant <name>] [--debounce <dur>]")
+	fmt.Fprintln(w, "  status  <submission-id> [--problem <problem-key>]")
+	fmt.Fprintln(w, "  submissions <problem-key> [--limit <n>]")
+	fmt.Fprintln(w, "  langs   <problem-key>")
+	fmt.Fprintln(w, "  refresh <problem-key> [--lang <lang>] [--file <path> | --variant <name>]")
+	fmt.Fprintln(w, "  fmt     <problem-key> [--lang <lang>] [--file <path> | --variant <name>]")
+	fmt.Fprintln(w, "  reset   <problem-key> [--lang <lang>] [--file <path> | --variant <name>] [--yes]")
+	fmt.Fprintln(w, "  pull    <problem-key> [--lang <lang>] [--force]")
+	fmt.Fprintln(w, "  sync    [--dir <root>] [--workers <n>] [--rate <req/s>] [--force]")
+	fmt.Fprintln(w, "  index   [--dir <root>]")
+	fmt.Fprintln(w, "  check-all [--root <dir>] [--run-only | --submit] [--concurrency <n>] [--rate <req/s>] [--report junit=<path>|tap]")
 	fmt.Fprintln(w, "  config  init|show")
+	fmt.Fprintln(w, "  mcp     (MCP server over stdio; submit requires mcp.allow_submit)")
+	fmt.Fprintln(w, "  serve   [--addr <host:port>] (HTTP API; requires serve.token)")
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Notes:")
 	fmt.Fprintln(w, "  - problem-key is the LeetCode titleSlug in MVP (e.g. two-sum)")
 	fmt.Fprintln(w, "  - Use --json on subcommands for JSON output")
+	fmt.Fprintln(w, "  - --variant <name> selects solution.<name>.<ext> next to solution.<ext>")
+	fmt.Fprintln(w, "  - Without --lang, submit/run/watch use the only language present in the workspace")
 }
@@ -5,11 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/therootusr/go-leetcode"
 	"vleet/internal/config"
 	"vleet/internal/editor"
+	"vleet/internal/errx"
+	"vleet/internal/format"
+	"vleet/internal/gitx"
+	"vleet/internal/history"
+	"vleet/internal/hooks"
+	"vleet/internal/lcx"
 	"vleet/internal/output"
 	"vleet/internal/render"
 	"vleet/internal/workspace"
@@ -19,6 +27,14 @@ const (
 	kDefaultLang = "cpp"
 )
 
+// kEditorSplitFiles are workspace files shown next to the solution when present.
+var kEditorSplitFiles = []string{"README.md", "testcases.txt"}
+
+var errNoSession = errx.Mark(
+	errors.New("leetcode.session is not set in config (run: vleet config init, then edit the config file)"),
+	errx.ErrAuth,
+)
+
 // App orchestrates the core components described in docs/architecture.md.
 type App struct {
 	ConfigStore config.Store
@@ -27,23 +43,54 @@ type App struct {
 	Renderer    render.Renderer
 	Editor      editor.Runner
 	Output      output.Printer
+
+	// History records submissions per workspace (optional).
+	History history.Store
+
+	// Git commits accepted solutions when git.autocommit is set (optional).
+	Git gitx.Committer
+
+	// Hooks runs the lifecycle hooks from the config (optional).
+	Hooks hooks.Runner
+
+	// Formatter runs the per-language formatters from the config (optional).
+	Formatter format.Formatter
+
+	// Progress, when set, is told about each step of a submit: submitted, polling
+	// (while LeetCode judges), then result or error. Printers subscribe to it, e.g.
+	// StdPrinter.PrintEvent (--events) or a Spinner.
+	Progress func(ctx context.Context, ev output.Event)
+
+	// Reports receive every run and submit result next to Output, e.g. a JUnit or TAP
+	// report (optional).
+	Reports []output.Reporter
 }
 
 type SolveOptions struct {
 	ProblemKey string // MVP: titleSlug
 	Lang       string // LeetCode language slug (default: config.DefaultLang)
+	File       string // optional solution file name/path override
+	Variant    string // optional named variant: solution.<variant>.<ext>
 	Submit     bool
 }
 
 type FetchOptions struct {
 	ProblemKey string
 	Lang       string
+	File       string
+	Variant    string
 }
 
 type SubmitOptions struct {
 	ProblemKey string
 	Lang       string
 	File       string // optional override; defaults to ./<problem-key>/solution.<ext>
+	Variant    string // optional named variant; mutually exclusive with File
+	Format     bool   // run the configured formatter on the code before submitting
+
+	// FailOnReject makes Submit return a *VerdictError when the verdict is not Accepted,
+	// after printing it as usual.
+	FailOnReject bool
 }
 
 func New(deps App) *App {
@@ -67,7 +114,7 @@ func (a *App) Solve(ctx context.Context, opts SolveOptions) error {
 		return err
 	}
 
-	prep, err := a.prepareSolutionFile(ctx, opts.ProblemKey, opts.Lang)
+	prep, err := a.prepareSolutionFile(ctx, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
 	if err != nil {
 		return err
 	}
@@ -84,7 +131,7 @@ func (a *App) Solve(ctx context.Context, opts SolveOptions) error {
 	if a.Editor == nil {
 		return fmt.Errorf("editor runner is not configured")
 	}
-	if err := a.Editor.OpenFile(ctx, cfg.Editor, prep.Workspace.SolutionPath); err != nil {
+	if err := a.Editor.OpenFile(ctx, cfg.Editor, prep.Workspace.SolutionPath, a.editorOpenOptions(ctx, prep)); err != nil {
 		return err
 	}
 
@@ -92,7 +139,8 @@ func (a *App) Solve(ctx context.Context, opts SolveOptions) error {
 		return a.Submit(ctx, SubmitOptions{
 			ProblemKey: opts.ProblemKey,
 			Lang:       prep.Lang,
-			File:       "",
+			File:       opts.File,
+			Variant:    opts.Variant,
 		})
 	}
 	return nil
@@ -108,7 +156,7 @@ func (a *App) Fetch(ctx context.Context, opts FetchOptions) error {
 		return fmt.Errorf("problem key (titleSlug) is required")
 	}
 
-	prep, err := a.prepareSolutionFile(ctx, opts.ProblemKey, opts.Lang)
+	prep, err := a.prepareSolutionFile(ctx, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
 	if err != nil {
 		return err
 	}
@@ -132,86 +180,301 @@ func (a *App) Fetch(ctx context.Context, opts FetchOptions) error {
 
 // Submit submits a solution from an existing workspace.
 // See docs/architecture.md "vleet submit" flow.
-//
-// Skeleton only: not implemented yet.
-func (a *App) Submit(ctx context.Context, opts SubmitOptions) error {
-	if err := ctx.Err(); err != nil {
+func (a *App) Submit(ctx context.Context, opts SubmitOptions) (err error) {
+	defer func() {
+		// A rejected verdict already went out as the result event.
+		var verr *VerdictError
+		if err != nil && !errors.As(err, &verr) {
+			a.progress(ctx, output.Event{Kind: output.EventError, Error: err.Error()})
+		}
+	}()
+
+	src, err := a.loadSource(ctx, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
 		return err
 	}
-	if strings.TrimSpace(opts.ProblemKey) == "" {
-		return fmt.Errorf("problem key (titleSlug) is required")
+
+	if cmd := src.Config.Hooks.PreSubmit; strings.TrimSpace(cmd) != "" && a.Hooks != nil {
+		if err := a.runHook(ctx, hooks.PreSubmit, cmd, src.Workspace, src.Lang, nil, nil); err != nil {
+			return fmt.Errorf("submit canceled: %w", err)
+		}
+		// The hook may have rewritten the solution (e.g. a formatter); submit what's on disk.
+		if src.Code, err = a.Workspace.ReadSolution(ctx, src.Workspace); err != nil {
+			return err
+		}
+	}
+
+	if opts.Format {
+		if src.Code, err = a.formatSolution(ctx, src.Config, src.Workspace, src.Lang, src.Code); err != nil {
+			return fmt.Errorf("submit canceled: %w", err)
+		}
+	}
+
+	a.setOutputSource(src)
+
+	submissionID, err := a.LeetCode.Submit(ctx, leetcode.SubmitRequest{
+		TitleSlug:  opts.ProblemKey,
+		QuestionID: src.Question.QuestionID,
+		Lang:       src.Lang,
+		TypedCode:  src.Code,
+	})
+	if err != nil {
+		return err
+	}
+	a.progress(ctx, output.Event{Kind: output.EventSubmitted, SubmissionID: int64(submissionID)})
+
+	polled, err := a.pollSubmission(ctx, submissionID)
+	if err != nil {
+		// The submission exists on LeetCode but we never saw its verdict (Ctrl-C, poll
+		// timeout, network). Record it so `vleet status` can pick it up later; use a
+		// non-canceled context so the record survives the interrupt.
+		pending := leetcode.SubmissionResult{State: history.StatePending}
+		if recErr := a.recordSubmission(context.WithoutCancel(ctx), src, submissionID, pending); recErr != nil {
+			a.printError(ctx, recErr)
+		}
+		return &PendingSubmissionError{SubmissionID: submissionID, ProblemKey: opts.ProblemKey, Err: err}
+	}
+
+	result := polled.SubmissionResult
+	if err := a.recordSubmission(ctx, src, submissionID, result); err != nil {
+		a.printError(ctx, err)
+	}
+	for _, r := range a.Reports {
+		r.AddSubmission(opts.ProblemKey, src.Lang, int64(submissionID), polled)
+	}
+	verdict := output.NewVerdict(result)
+	a.progress(ctx, output.Event{Kind: output.EventResult, SubmissionID: int64(submissionID), Result: &verdict})
+
+	if a.Output != nil {
+		if err := a.Output.PrintSubmissionResult(ctx, result); err != nil {
+			return err
+		}
+	}
+
+	a.postSubmitHook(ctx, src, submissionID, result)
+	a.autoCommit(ctx, src, result)
+
+	if opts.FailOnReject && result.Status != kAcceptedStatus {
+		return &VerdictError{SubmissionID: submissionID, ProblemKey: opts.ProblemKey, Result: result}
+	}
+	return nil
+}
+
+// pollSubmission waits for the verdict. When the client can (lcx.ProgressPoller), each
+// check is reported to Progress and a rejected verdict comes with its failing testcase.
+func (a *App) pollSubmission(ctx context.Context, id leetcode.SubmissionID) (lcx.SubmissionResult, error) {
+	if poller, ok := a.LeetCode.(lcx.ProgressPoller); ok {
+		return poller.PollSubmissionProgress(ctx, id, leetcode.PollOptions{}, func(state string, attempt int) {
+			a.progress(ctx, output.Event{Kind: output.EventPolling, SubmissionID: int64(id), State: state, Attempt: attempt})
+		})
+	}
+	r, err := a.LeetCode.PollSubmission(ctx, id, leetcode.PollOptions{})
+	return lcx.SubmissionResult{SubmissionResult: r}, err
+}
+
+func (a *App) progress(ctx context.Context, ev output.Event) {
+	if a.Progress != nil {
+		a.Progress(ctx, ev)
+	}
+}
+
+// PendingSubmissionError reports a submission that LeetCode accepted but whose verdict was
+// not observed because polling stopped early. It wraps the polling error, so
+// errors.Is(err, context.Canceled) identifies an interrupt.
+type PendingSubmissionError struct {
+	SubmissionID leetcode.SubmissionID
+	ProblemKey   string
+	Err          error
+}
+
+func (e *PendingSubmissionError) Error() string {
+	resume := fmt.Sprintf("vleet status --problem %s %d", e.ProblemKey, e.SubmissionID)
+	if errors.Is(e.Err, context.Canceled) {
+		return fmt.Sprintf("interrupted; submission %d is pending on LeetCode (resume with: %s)", e.SubmissionID, resume)
+	}
+	return fmt.Sprintf("polling submission %d: %v (resume with: %s)", e.SubmissionID, e.Err, resume)
+}
+
+func (e *PendingSubmissionError) Unwrap() error { return e.Err }
+
+// VerdictError reports a judged submission that was not accepted (see
+// SubmitOptions.FailOnReject). It matches errx.ErrNotAccepted and, for the common
+// verdicts, a narrower kind such as errx.ErrWrongAnswer.
+type VerdictError struct {
+	SubmissionID leetcode.SubmissionID
+	ProblemKey   string
+	Result       leetcode.SubmissionResult
+}
+
+func (e *VerdictError) Error() string {
+	status := e.Result.Status
+	if status == "" {
+		status = "no verdict (state " + e.Result.State + ")"
+	}
+	return fmt.Sprintf("%s: submission %d was not accepted: %s", e.ProblemKey, e.SubmissionID, status)
+}
+
+func (e *VerdictError) Unwrap() []error {
+	if kind := verdictKind(e.Result.Status); kind != nil {
+		return []error{kind, errx.ErrNotAccepted}
+	}
+	return []error{errx.ErrNotAccepted}
+}
+
+// verdictKind maps LeetCode's status_msg to an errx verdict kind, or nil for the rarer
+// verdicts (e.g. "Output Limit Exceeded").
+func verdictKind(status string) error {
+	switch status {
+	case "Wrong Answer":
+		return errx.ErrWrongAnswer
+	case "Time Limit Exceeded":
+		return errx.ErrTimeLimit
+	case "Memory Limit Exceeded":
+		return errx.ErrMemoryLimit
+	case "Runtime Error":
+		return errx.ErrRuntimeError
+	case "Compile Error":
+		return errx.ErrCompileError
+	}
+	return nil
+}
+
+func (a *App) recordSubmission(ctx context.Context, src source, id leetcode.SubmissionID, r leetcode.SubmissionResult) error {
+	if a.History == nil {
+		return nil
+	}
+	file := src.Workspace.SolutionPath
+	if rel, err := filepath.Rel(src.Workspace.Dir, file); err == nil {
+		file = rel
+	}
+	return a.History.Append(ctx, src.Workspace.Dir, newHistoryRecord(src.Workspace.ProblemKey, src.Lang, file, id, r))
+}
+
+func newHistoryRecord(problemKey string, lang string, file string, id leetcode.SubmissionID, r leetcode.SubmissionResult) history.Record {
+	return history.Record{
+		Time:         time.Now().UTC(),
+		SubmissionID: int64(id),
+		ProblemKey:   problemKey,
+		Lang:         lang,
+		File:         file,
+		Variant:      workspace.VariantOf(file),
+		State:        r.State,
+		Status:       r.Status,
+		Runtime:      r.Runtime,
+		Memory:       r.Memory,
+	}
+}
+
+// source is a solution read from an existing workspace, ready to send to LeetCode.
+type source struct {
+	Workspace workspace.Workspace
+	Question  leetcode.Question
+	Lang      string
+	Code      string
+	Config    config.Config
+}
+
+// loadSource performs the shared prelude of submit/run: require auth, resolve the
+// language, load the workspace + solution and re-fetch the question for its question_id.
+func (a *App) loadSource(ctx context.Context, problemKey string, langFlag string, file string, variant string) (source, error) {
+	if err := ctx.Err(); err != nil {
+		return source{}, err
+	}
+	if strings.TrimSpace(problemKey) == "" {
+		return source{}, fmt.Errorf("problem key (titleSlug) is required")
 	}
 	if a.LeetCode == nil {
-		return fmt.Errorf("leetcode client is not configured")
+		return source{}, fmt.Errorf("leetcode client is not configured")
 	}
 	if a.Workspace == nil {
-		return fmt.Errorf("workspace manager is not configured")
+		return source{}, fmt.Errorf("workspace manager is not configured")
 	}
 
 	cfg, err := a.loadConfigRequired(ctx)
 	if err != nil {
-		return err
+		return source{}, err
 	}
 	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
-		return fmt.Errorf("leetcode.session is not set in config (run: vleet config init, then edit the config file)")
+		return source{}, errNoSession
 	}
 
-	lang := strings.TrimSpace(opts.Lang)
-	if lang == "" {
-		lang = strings.TrimSpace(cfg.DefaultLang)
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return source{}, err
 	}
-	if lang == "" {
-		lang = kDefaultLang
+	lang, err := a.sourceLang(cfg, root, problemKey, langFlag, file, variant)
+	if err != nil {
+		return source{}, err
 	}
-
-	// If we're using the built-in HTTP client, inject auth for submit/poll.
-	if hc, ok := a.LeetCode.(*leetcode.HttpClient); ok {
-		hc.Auth = leetcode.Auth{
-			Session:   cfg.LeetCode.Session,
-			CsrfToken: cfg.LeetCode.CSRFTOKEN,
-		}
+	file, err = solutionFileOption(lang, file, variant)
+	if err != nil {
+		return source{}, err
 	}
 
-	ws, err := a.Workspace.LoadWorkspace(ctx, ".", opts.ProblemKey, lang, opts.File)
+	a.injectAuth(cfg)
+
+	ws, err := a.Workspace.LoadWorkspace(ctx, root, problemKey, lang, file)
 	if err != nil {
-		return err
+		return source{}, workspaceError(err, root, problemKey)
 	}
 
 	code, err := a.Workspace.ReadSolution(ctx, ws)
 	if err != nil {
-		return err
+		return source{}, err
 	}
 
-	q, err := a.LeetCode.FetchQuestion(ctx, opts.ProblemKey)
+	q, err := a.LeetCode.FetchQuestion(ctx, problemKey)
 	if err != nil {
-		return err
+		return source{}, err
 	}
 	if strings.TrimSpace(q.QuestionID) == "" {
-		return fmt.Errorf("missing question_id for problem %s", opts.ProblemKey)
+		return source{}, fmt.Errorf("missing question_id for problem %s", problemKey)
 	}
 
-	submissionID, err := a.LeetCode.Submit(ctx, leetcode.SubmitRequest{
-		TitleSlug:  opts.ProblemKey,
-		QuestionID: q.QuestionID,
-		Lang:       lang,
-		TypedCode:  code,
-	})
-	if err != nil {
-		return err
+	return source{Workspace: ws, Question: q, Lang: lang, Code: code, Config: cfg}, nil
+}
+
+// setOutputSource tells the printer how submitted lines map to the solution file so
+// errors can be reported against it (see output.StdPrinter.ErrorFormat). The whole file,
+// header included, is sent verbatim, so the mapping is the identity.
+func (a *App) setOutputSource(src source) {
+	if sp, ok := a.Output.(*output.StdPrinter); ok {
+		sp.Source = output.NewSourceMap(src.Workspace.SolutionPath, strings.Count(strings.TrimSuffix(src.Code, "\n"), "\n")+1)
 	}
+}
 
-	result, err := a.LeetCode.PollSubmission(ctx, submissionID, leetcode.PollOptions{})
-	if err != nil {
-		return err
+// injectAuth sets session cookies on the built-in HTTP clients for authenticated endpoints.
+func (a *App) injectAuth(cfg config.Config) {
+	auth := leetcode.Auth{
+		Session:   cfg.LeetCode.Session,
+		CsrfToken: cfg.LeetCode.CSRFTOKEN,
+	}
+	switch c := a.LeetCode.(type) {
+	case *leetcode.HttpClient:
+		c.Auth = auth
+	case *lcx.HttpClient:
+		c.Auth = auth
 	}
+}
 
-	if a.Output != nil {
-		if err := a.Output.PrintSubmissionResult(ctx, result); err != nil {
-			return err
+// editorOpenOptions places the cursor on the first line of the starter snippet and
+// lists existing companion files (see kEditorSplitFiles) for split views.
+func (a *App) editorOpenOptions(ctx context.Context, prep preparedSolution) editor.OpenOptions {
+	var opts editor.OpenOptions
+
+	if a.Workspace != nil {
+		if content, err := a.Workspace.ReadSolution(ctx, prep.Workspace); err == nil {
+			opts.Line = render.BodyStartLine(prep.Lang, content)
 		}
 	}
 
-	return nil
+	for _, name := range kEditorSplitFiles {
+		p := filepath.Join(prep.Workspace.Dir, name)
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+			opts.Splits = append(opts.Splits, p)
+		}
+	}
+	return opts
 }
 
 type preparedSolution struct {
@@ -222,7 +485,60 @@ type preparedSolution struct {
 	SelectedSnippet leetcode.CodeSnippet
 }
 
-func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFlag string) (preparedSolution, error) {
+// sourceLang resolves the language of an existing solution: --lang, else inferred from
+// the workspace (see inferLang), else default_lang.
+func (a *App) sourceLang(cfg config.Config, root string, problemKey string, langFlag string, file string, variant string) (string, error) {
+	if lang := strings.TrimSpace(langFlag); lang != "" {
+		return lang, nil
+	}
+	lang, err := inferLang(root, problemKey, file, variant)
+	if err != nil || lang != "" {
+		return lang, err
+	}
+	if lang = strings.TrimSpace(cfg.DefaultLang); lang != "" {
+		return lang, nil
+	}
+	return kDefaultLang, nil
+}
+
+// solutionFileOption resolves the --file/--variant pair into the file argument of the
+// workspace manager ("" selects the default solution.<ext>).
+func solutionFileOption(lang string, file string, variant string) (string, error) {
+	if strings.TrimSpace(variant) == "" {
+		return file, nil
+	}
+	if strings.TrimSpace(file) != "" {
+		return "", fmt.Errorf("use either --file or --variant, not both")
+	}
+	return workspace.VariantFile(lang, variant)
+}
+
+// starterSolution fetches the question and renders the initial solution file for lang:
+// the generated header followed by the starter snippet.
+func (a *App) starterSolution(ctx context.Context, problemKey string, lang string) (leetcode.Question, leetcode.CodeSnippet, string, error) {
+	q, err := a.LeetCode.FetchQuestion(ctx, problemKey)
+	if err != nil {
+		return leetcode.Question{}, leetcode.CodeSnippet{}, "", err
+	}
+
+	snippet, err := selectSnippet(q.CodeSnippets, lang)
+	if err != nil {
+		return leetcode.Question{}, leetcode.CodeSnippet{}, "", err
+	}
+
+	header, err := a.Renderer.RenderHeader(ctx, lang, q)
+	if err != nil {
+		return leetcode.Question{}, leetcode.CodeSnippet{}, "", err
+	}
+
+	content := header + "\n" + snippet.Code
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return q, snippet, content, nil
+}
+
+func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFlag string, file string, variant string) (preparedSolution, error) {
 	if a.LeetCode == nil {
 		return preparedSolution{}, fmt.Errorf("leetcode client is not configured")
 	}
@@ -245,27 +561,25 @@ func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFl
 	if lang == "" {
 		lang = kDefaultLang
 	}
-
-	q, err := a.LeetCode.FetchQuestion(ctx, problemKey)
+	file, err = solutionFileOption(lang, file, variant)
 	if err != nil {
 		return preparedSolution{}, err
 	}
 
-	snippet, err := selectSnippet(q.CodeSnippets, lang)
+	q, snippet, content, err := a.starterSolution(ctx, problemKey, lang)
 	if err != nil {
 		return preparedSolution{}, err
 	}
 
-	header, err := a.Renderer.RenderHeader(ctx, lang, q)
+	root, err := cfg.Workspace.RootDir()
 	if err != nil {
 		return preparedSolution{}, err
 	}
-
-	ws, err := a.Workspace.CreateWorkspace(ctx, ".", q, lang, workspace.CreateOptions{})
+	ws, err := a.Workspace.CreateWorkspace(ctx, root, q, lang, workspace.CreateOptions{File: file, Layout: cfg.Workspace.Layout})
 	if err != nil {
 		if errors.Is(err, os.ErrExist) {
 			// Workspace already exists; load it and don't overwrite.
-			ws, loadErr := a.Workspace.LoadWorkspace(ctx, ".", q.TitleSlug, lang, "")
+			ws, loadErr := a.Workspace.LoadWorkspace(ctx, root, q.TitleSlug, lang, file)
 			if loadErr != nil {
 				return preparedSolution{}, err
 			}
@@ -280,11 +594,6 @@ func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFl
 		return preparedSolution{}, err
 	}
 
-	content := header + "\n" + snippet.Code
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
-	}
-
 	if err := a.Workspace.WriteSolution(ctx, ws, content); err != nil {
 		if errors.Is(err, os.ErrExist) {
 			// Race: file created by another process between CreateWorkspace and WriteSolution.
@@ -299,6 +608,10 @@ func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFl
 		return preparedSolution{}, err
 	}
 
+	if err := a.runHook(ctx, hooks.PostCreate, cfg.Hooks.PostCreate, ws, lang, nil, nil); err != nil {
+		a.printError(ctx, err)
+	}
+
 	return preparedSolution{
 		Workspace:       ws,
 		Question:        q,
@@ -308,6 +621,42 @@ func (a *App) prepareSolutionFile(ctx context.Context, problemKey string, langFl
 	}, nil
 }
 
+// existingWorkspace resolves the language (see sourceLang) and solution file of an
+// existing workspace and loads it.
+func (a *App) existingWorkspace(ctx context.Context, cfg config.Config, problemKey string, langFlag string, file string, variant string) (workspace.Workspace, string, error) {
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	lang, err := a.sourceLang(cfg, root, problemKey, langFlag, file, variant)
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	file, err = solutionFileOption(lang, file, variant)
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	ws, err := a.Workspace.LoadWorkspace(ctx, root, problemKey, lang, file)
+	if err != nil {
+		return workspace.Workspace{}, "", workspaceError(err, root, problemKey)
+	}
+	return ws, lang, nil
+}
+
+// errNoWorkspace reports a problem without a workspace under root.
+func errNoWorkspace(root string, problemKey string) error {
+	return errx.Mark(fmt.Errorf("no workspace for %s under %s (run: vleet fetch %s)", problemKey, root, problemKey), errx.ErrNotFound)
+}
+
+// workspaceError turns a LoadWorkspace error for a missing workspace dir into
+// errNoWorkspace.
+func workspaceError(err error, root string, problemKey string) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return errNoWorkspace(root, problemKey)
+	}
+	return err
+}
+
 func (a *App) loadConfigOrDefault(ctx context.Context) (config.Config, error) {
 	if a.ConfigStore == nil {
 		return config.Config{}, nil
@@ -357,7 +706,10 @@ func selectSnippet(snippets []leetcode.CodeSnippet, lang string) (leetcode.CodeS
 	}
 
 	if len(available) == 0 {
-		return leetcode.CodeSnippet{}, fmt.Errorf("no code snippets available for this problem")
+		return leetcode.CodeSnippet{}, errx.Mark(fmt.Errorf("no code snippets available for this problem"), errx.ErrPremium)
 	}
-	return leetcode.CodeSnippet{}, fmt.Errorf("no snippet for lang %q (available: %s)", lang, strings.Join(available, ", "))
+	return leetcode.CodeSnippet{}, errx.Mark(
+		fmt.Errorf("no snippet for lang %q (available: %s)", lang, strings.Join(available, ", ")),
+		errx.ErrUnsupportedLang,
+	)
 }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/therootusr/go-leetcode"
 	"vleet/internal/config"
+	"vleet/internal/editor"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
 	"vleet/internal/workspace"
 )
 
@@ -65,6 +69,7 @@ type fakeWorkspaceManager struct {
 	wrotePath    string
 	wroteContent string
 	writeErr     error
+	replaced     bool
 
 	loadCalled bool
 	loadErr    error
@@ -107,15 +112,28 @@ func (m *fakeWorkspaceManager) WriteSolution(ctx context.Context, ws workspace.W
 	return m.writeErr
 }
 
+func (m *fakeWorkspaceManager) ArchiveSolution(ctx context.Context, ws workspace.Workspace) (string, error) {
+	return "", errors.New("not needed in tests")
+}
+
+func (m *fakeWorkspaceManager) ReplaceSolution(ctx context.Context, ws workspace.Workspace, content string) error {
+	m.replaced = true
+	m.wrotePath = ws.SolutionPath
+	m.wroteContent = content
+	return m.writeErr
+}
+
 type fakeEditor struct {
 	gotEditorCmd string
 	gotFilePath  string
+	gotOpts      editor.OpenOptions
 	err          error
 }
 
-func (e *fakeEditor) OpenFile(ctx context.Context, editorCmd string, filePath string) error {
+func (e *fakeEditor) OpenFile(ctx context.Context, editorCmd string, filePath string, opts editor.OpenOptions) error {
 	e.gotEditorCmd = editorCmd
 	e.gotFilePath = filePath
+	e.gotOpts = opts
 	return e.err
 }
 
@@ -135,6 +153,22 @@ func (o *fakeOutput) PrintSubmissionResult(ctx context.Context, r leetcode.Submi
 	return errors.New("not needed in tests")
 }
 
+func (o *fakeOutput) PrintRunResult(ctx context.Context, r lcx.RunResult) error {
+	return errors.New("not needed in tests")
+}
+
+func (o *fakeOutput) PrintSubmissions(ctx context.Context, subs []lcx.Submission) error {
+	return errors.New("not needed in tests")
+}
+
+func (o *fakeOutput) PrintLangs(ctx context.Context, langs []output.LangStatus) error {
+	return errors.New("not needed in tests")
+}
+
+func (o *fakeOutput) PrintCheckResults(ctx context.Context, results []output.CheckResult) error {
+	return errors.New("not needed in tests")
+}
+
 func (o *fakeOutput) PrintError(ctx context.Context, err error) error { return nil }
 
 func TestApp_Fetch_Sanity_WritesSolution(t *testing.T) {
@@ -239,6 +273,48 @@ func TestApp_Solve_Sanity_OpensEditor(t *testing.T) {
 	}
 }
 
+func TestApp_Solve_OpensEditorAtSnippetLine_WithSplits(t *testing.T) {
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(readme, []byte("notes\n"), 0o644); err != nil {
+		t.Fatalf("write README: %v", err)
+	}
+
+	ed := &fakeEditor{}
+	wm := &fakeWorkspaceManager{
+		ws: workspace.Workspace{
+			Dir:          dir,
+			ProblemKey:   "two-sum",
+			Lang:         "cpp",
+			SolutionPath: filepath.Join(dir, "solution.cpp"),
+		},
+		readSolution: "// Two Sum\n//\n// desc\n\nclass Solution {};\n",
+	}
+
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{Editor: "vim", DefaultLang: "cpp"}},
+		LeetCode: &fakeLeetCodeClient{
+			q: leetcode.Question{
+				TitleSlug:    "two-sum",
+				CodeSnippets: []leetcode.CodeSnippet{{LangSlug: "cpp", Code: "class Solution {};"}},
+			},
+		},
+		Workspace: wm,
+		Renderer:  &fakeRenderer{header: "// Two Sum\n//\n// desc\n"},
+		Editor:    ed,
+	})
+
+	if err := a.Solve(context.Background(), SolveOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if ed.gotOpts.Line != 5 {
+		t.Fatalf("OpenFile line = %d, want %d", ed.gotOpts.Line, 5)
+	}
+	if len(ed.gotOpts.Splits) != 1 || ed.gotOpts.Splits[0] != readme {
+		t.Fatalf("OpenFile splits = %v, want [%s]", ed.gotOpts.Splits, readme)
+	}
+}
+
 func TestApp_Fetch_WhenWorkspaceExists_DoesNotOverwrite(t *testing.T) {
 	// This asserts the "no overwrite" control flow at the app layer: if the workspace
 	// manager indicates existence via os.ErrExist, App should fall back to LoadWorkspace
@@ -0,0 +1,371 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+const (
+	kDefaultCheckConcurrency = 2
+	kDefaultCheckRate        = 1 // requests per second
+
+	kCheckModeRun    = "run"
+	kCheckModeSubmit = "submit"
+	kCheckStatusErr  = "Error"
+)
+
+type CheckAllOptions struct {
+	// Root is the solutions directory (default: workspace.root from config, else ".").
+	Root string
+
+	// Submit re-submits every solution instead of running the examples. Submissions count
+	// toward the account's history on LeetCode.
+	Submit bool
+
+	// Concurrency bounds how many problems are checked at once (default: 2).
+	Concurrency int
+
+	// Rate caps LeetCode requests per second across all workers (default: 1), polls for
+	// a verdict included.
+	Rate float64
+}
+
+// CheckAll checks the default solution file of every language in every workspace under
+// root, running the examples (or re-submitting with opts.Submit), and prints a summary.
+// Each result is also passed to a.Reports. It fails when any check did not pass.
+func (a *App) CheckAll(ctx context.Context, opts CheckAllOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+	runner, ok := a.LeetCode.(lcx.Runner)
+	if !ok && !opts.Submit {
+		return fmt.Errorf("leetcode client does not support running code")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
+		return errNoSession
+	}
+	a.injectAuth(cfg)
+
+	root := strings.TrimSpace(opts.Root)
+	if root == "" {
+		if root, err = cfg.Workspace.RootDir(); err != nil {
+			return err
+		}
+	}
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = kDefaultCheckConcurrency
+	}
+	rate := opts.Rate
+	if rate <= 0 {
+		rate = kDefaultCheckRate
+	}
+
+	jobs, err := collectCheckJobs(root)
+	if err != nil {
+		return err
+	}
+	total := 0
+	for _, j := range jobs {
+		total += len(j.langs)
+	}
+	mode := kCheckModeRun
+	if opts.Submit {
+		mode = kCheckModeSubmit
+	}
+	a.statusf("Checking %d solution(s) in %d problem(s) (%s)\n", total, len(jobs), mode)
+
+	limiter := newRateLimiter(rate)
+	defer limiter.Stop()
+
+	c := &checker{app: a, root: root, mode: mode, runner: runner, limiter: limiter, total: total}
+	if hc, ok := a.LeetCode.(*lcx.HttpClient); ok {
+		// Every HTTP request waits on the limiter, including each poll of the check
+		// endpoint. Other clients wait once per call (see checker.wait).
+		limited := rateLimitedClient(hc, limiter)
+		ca := *a
+		ca.LeetCode = limited
+		c.app, c.runner, c.perRequest = &ca, limited, true
+	}
+	c.run(ctx, jobs, workers)
+
+	results := c.results
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Slug != results[j].Slug {
+			return results[i].Slug < results[j].Slug
+		}
+		return results[i].Lang < results[j].Lang
+	})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if a.Output != nil {
+		if err := a.Output.PrintCheckResults(ctx, results); err != nil {
+			return err
+		}
+	}
+
+	if len(c.errs) > 0 {
+		return fmt.Errorf("check-all: %d check(s) did not complete (re-run to retry): %w", len(c.errs), errors.Join(c.errs...))
+	}
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errx.Mark(fmt.Errorf("check-all: %d of %d solution(s) did not pass", failed, len(results)), errx.ErrNotAccepted)
+	}
+	return nil
+}
+
+// checkJob is one problem; its languages are checked one after another so the question
+// is fetched once.
+type checkJob struct {
+	slug  string
+	langs []string
+}
+
+func collectCheckJobs(root string) ([]checkJob, error) {
+	located, err := workspace.ListWorkspaces(root)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []checkJob
+	for _, l := range located {
+		files, err := workspace.SolutionFiles(filepath.Join(root, filepath.FromSlash(l.Dir)))
+		if err != nil {
+			return nil, err
+		}
+		job := checkJob{slug: l.Slug}
+		for lang := range files {
+			job.langs = append(job.langs, lang)
+		}
+		if len(job.langs) == 0 {
+			continue
+		}
+		sort.Strings(job.langs)
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].slug < jobs[j].slug })
+	return jobs, nil
+}
+
+type checker struct {
+	app     *App
+	root    string
+	mode    string
+	runner  lcx.Runner
+	limiter *rateLimiter
+	total   int
+
+	// perRequest is set when the client's transport waits on limiter itself.
+	perRequest bool
+
+	mu      sync.Mutex
+	done    int
+	results []output.CheckResult
+	errs    []error
+}
+
+func (c *checker) run(ctx context.Context, jobs []checkJob, workers int) {
+	ch := make(chan checkJob)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(jobs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				c.checkProblem(ctx, job)
+			}
+		}()
+	}
+
+feed:
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			break feed
+		case ch <- job:
+		}
+	}
+	close(ch)
+	wg.Wait()
+}
+
+func (c *checker) checkProblem(ctx context.Context, job checkJob) {
+	var q leetcode.Question
+	qErr := c.wait(ctx)
+	if qErr == nil {
+		q, qErr = c.app.LeetCode.FetchQuestion(ctx, job.slug)
+	}
+	for _, lang := range job.langs {
+		r := output.CheckResult{Slug: job.slug, Lang: lang, Mode: c.mode}
+		err := qErr
+		if err == nil {
+			r, err = c.checkOne(ctx, q, r)
+		}
+		if ctx.Err() != nil {
+			return // the cancellation is reported once by CheckAll
+		}
+		if err != nil {
+			r.Status, r.Passed, r.Error = kCheckStatusErr, false, err.Error()
+		}
+		c.finish(r, err)
+	}
+}
+
+func (c *checker) checkOne(ctx context.Context, q leetcode.Question, r output.CheckResult) (output.CheckResult, error) {
+	a := c.app
+	ws, err := a.Workspace.LoadWorkspace(ctx, c.root, r.Slug, r.Lang, "")
+	if err != nil {
+		return r, err
+	}
+	code, err := a.Workspace.ReadSolution(ctx, ws)
+	if err != nil {
+		return r, err
+	}
+	if strings.TrimSpace(q.QuestionID) == "" {
+		return r, fmt.Errorf("missing question_id for problem %s", r.Slug)
+	}
+
+	if c.mode == kCheckModeSubmit {
+		if err := c.wait(ctx); err != nil {
+			return r, err
+		}
+		id, err := a.LeetCode.Submit(ctx, leetcode.SubmitRequest{TitleSlug: r.Slug, QuestionID: q.QuestionID, Lang: r.Lang, TypedCode: code})
+		if err != nil {
+			return r, err
+		}
+		polled, err := a.pollSubmission(ctx, id)
+		if err != nil {
+			return r, fmt.Errorf("polling submission %d: %w", id, err)
+		}
+		res := polled.SubmissionResult
+		src := source{Workspace: ws, Question: q, Lang: r.Lang, Code: code}
+		if err := a.recordSubmission(ctx, src, id, res); err != nil {
+			a.printError(ctx, err)
+		}
+		for _, rep := range a.Reports {
+			rep.AddSubmission(r.Slug, r.Lang, int64(id), polled)
+		}
+		r.SubmissionID, r.Status, r.Runtime = int64(id), res.Status, res.Runtime
+		r.Passed = res.Status == kAcceptedStatus
+		return r, nil
+	}
+
+	input, err := exampleInput(q)
+	if err != nil {
+		return r, err
+	}
+	if err := c.wait(ctx); err != nil {
+		return r, err
+	}
+	runID, err := c.runner.RunCode(ctx, lcx.RunRequest{TitleSlug: r.Slug, QuestionID: q.QuestionID, Lang: r.Lang, TypedCode: code, DataInput: input})
+	if err != nil {
+		return r, err
+	}
+	res, err := c.runner.PollRun(ctx, runID, leetcode.PollOptions{})
+	if err != nil {
+		return r, err
+	}
+	for _, rep := range a.Reports {
+		rep.AddRun(r.Slug, r.Lang, input, res)
+	}
+	r.Status, r.Runtime = res.Status, res.Runtime
+	r.TotalCorrect, r.TotalTestcases = res.TotalCorrect, res.TotalTestcases
+	r.Passed = res.Correct && res.CompileError == "" && res.RuntimeError == ""
+	if res.TotalTestcases > 0 && res.CompileError == "" && res.RuntimeError == "" {
+		// LeetCode reports a finished run as "Accepted" whether or not the answers match.
+		r.Status = "Wrong Answer"
+		if res.Correct {
+			r.Status = kAcceptedStatus
+		}
+	}
+	return r, nil
+}
+
+// wait takes a token before a call to the LeetCode client, unless the client's transport
+// takes one per request.
+func (c *checker) wait(ctx context.Context) error {
+	if c.perRequest {
+		return ctx.Err()
+	}
+	return c.limiter.Wait(ctx)
+}
+
+func (c *checker) finish(r output.CheckResult, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done++
+	c.results = append(c.results, r)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("%s (%s): %w", r.Slug, r.Lang, err))
+	}
+	c.app.statusf("[%d/%d] %s (%s): %s\n", c.done, c.total, r.Slug, r.Lang, r.Status)
+}
+
+// rateLimitedClient returns a copy of hc whose HTTP requests each wait on limiter.
+func rateLimitedClient(hc *lcx.HttpClient, limiter *rateLimiter) *lcx.HttpClient {
+	c := hc.Clone()
+	base := http.DefaultClient
+	if c.Http != nil {
+		base = c.Http
+	}
+	limited := *base
+	limited.Transport = &rateLimitedTransport{base: base.Transport, limiter: limiter}
+	c.Http = &limited
+	return c
+}
+
+// rateLimitedTransport waits on limiter before each request.
+type rateLimitedTransport struct {
+	base    http.RoundTripper // nil means http.DefaultTransport
+	limiter *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// statusf prints a progress line on stderr (human output only), keeping stdout for the
+// result.
+func (a *App) statusf(format string, args ...any) {
+	if sp, ok := a.Output.(*output.StdPrinter); ok && !sp.JSON {
+		_, _ = fmt.Fprintf(sp.Err, format, args...)
+	}
+}
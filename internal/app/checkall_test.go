@@ -0,0 +1,257 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/errx"
+	"vleet/internal/history"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// fakeCheckClient judges code containing "BUG" as wrong and fails to fetch "broken".
+type fakeCheckClient struct {
+	mu    sync.Mutex
+	codes map[leetcode.SubmissionID]string
+	runs  []string
+}
+
+func (c *fakeCheckClient) FetchQuestion(ctx context.Context, titleSlug string) (leetcode.Question, error) {
+	if titleSlug == "broken" {
+		return leetcode.Question{}, errx.Mark(errors.New("leetcode graphql: status 429: slow down"), errx.ErrBlocked)
+	}
+	return leetcode.Question{QuestionID: "1", TitleSlug: titleSlug, ExampleTestcases: "[1]\n1"}, nil
+}
+
+func (c *fakeCheckClient) Submit(ctx context.Context, req leetcode.SubmitRequest) (leetcode.SubmissionID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.codes == nil {
+		c.codes = map[leetcode.SubmissionID]string{}
+	}
+	id := leetcode.SubmissionID(len(c.codes) + 1)
+	c.codes[id] = req.TypedCode
+	return id, nil
+}
+
+func (c *fakeCheckClient) PollSubmission(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions) (leetcode.SubmissionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if strings.Contains(c.codes[id], "BUG") {
+		return leetcode.SubmissionResult{State: "SUCCESS", Status: "Wrong Answer"}, nil
+	}
+	return leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "1 ms"}, nil
+}
+
+func (c *fakeCheckClient) RunCode(ctx context.Context, req lcx.RunRequest) (lcx.RunID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs = append(c.runs, req.TitleSlug+"/"+req.Lang)
+	return lcx.RunID(req.TypedCode), nil
+}
+
+func (c *fakeCheckClient) PollRun(ctx context.Context, id lcx.RunID, opts leetcode.PollOptions) (lcx.RunResult, error) {
+	r := lcx.RunResult{State: "SUCCESS", Status: "Accepted", TotalTestcases: 1, ExpectedAnswer: []string{"1"}, CodeAnswer: []string{"1"}}
+	if strings.Contains(string(id), "BUG") {
+		r.CodeAnswer = []string{"2"}
+	} else {
+		r.Correct, r.TotalCorrect = true, 1
+	}
+	return r, nil
+}
+
+func newCheckAllTestApp(t *testing.T) (*App, string, *fakeCheckClient, *bytes.Buffer) {
+	t.Helper()
+	root := t.TempDir()
+	for path, code := range map[string]string{
+		"two-sum/solution.cpp":         "OK\n",
+		"two-sum/solution.go":          "BUG\n",
+		"add-two-numbers/solution.cpp": "OK\n",
+		"broken/solution.cpp":          "OK\n",
+	} {
+		p := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(code), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	lc := &fakeCheckClient{}
+	var out bytes.Buffer
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{LeetCode: config.LeetCodeAuth{Session: "sess"}}},
+		LeetCode:    lc,
+		Workspace:   workspace.NewFSManager(),
+		Output:      output.NewStdPrinter(&out, &bytes.Buffer{}, true),
+		History:     history.NewFileStore(),
+	})
+	return a, root, lc, &out
+}
+
+func decodeCheckResults(t *testing.T, out *bytes.Buffer) []output.CheckResult {
+	t.Helper()
+	var env struct {
+		Kind string               `json:"kind"`
+		Data []output.CheckResult `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &env); err != nil || env.Kind != output.KindCheckResults {
+		t.Fatalf("output = %q (err=%v)", out.String(), err)
+	}
+	return env.Data
+}
+
+func TestApp_CheckAll_RunsEveryLanguageAndSummarizes(t *testing.T) {
+	t.Parallel()
+	a, root, lc, out := newCheckAllTestApp(t)
+	report := &output.Report{Format: output.ReportTAP}
+	a.Reports = []output.Reporter{report}
+
+	err := a.CheckAll(context.Background(), CheckAllOptions{Root: root, Concurrency: 3, Rate: 1000})
+	if !errors.Is(err, errx.ErrBlocked) {
+		t.Fatalf("CheckAll() error = %v, want the blocked fetch to surface", err)
+	}
+
+	got := decodeCheckResults(t, out)
+	want := []string{
+		"add-two-numbers/cpp run true Accepted",
+		"broken/cpp run false Error",
+		"two-sum/cpp run true Accepted",
+		"two-sum/golang run false Wrong Answer",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("results = %+v", got)
+	}
+	for i, r := range got {
+		line := fmt.Sprintf("%s/%s %s %t %s", r.Slug, r.Lang, r.Mode, r.Passed, r.Status)
+		if line != want[i] {
+			t.Fatalf("result %d = %q, want %q", i, line, want[i])
+		}
+	}
+	if !strings.Contains(got[1].Error, "status 429") {
+		t.Fatalf("broken error = %q", got[1].Error)
+	}
+	if len(lc.runs) != 3 {
+		t.Fatalf("runs = %v, want one per fetched solution", lc.runs)
+	}
+
+	var tap bytes.Buffer
+	if err := report.Write(&tap); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if !strings.Contains(tap.String(), "1..3\n") || !strings.Contains(tap.String(), "not ok 3 - two-sum: golang example 1") {
+		t.Fatalf("report:\n%s", tap.String())
+	}
+}
+
+func TestApp_CheckAll_Submit_RecordsHistoryAndFailsOnReject(t *testing.T) {
+	t.Parallel()
+	a, root, lc, out := newCheckAllTestApp(t)
+	if err := os.RemoveAll(filepath.Join(root, "broken")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	err := a.CheckAll(context.Background(), CheckAllOptions{Root: root, Submit: true, Rate: 1000})
+	if errx.ExitCode(err) != errx.ExitNotAccepted {
+		t.Fatalf("CheckAll() error = %v, want not accepted", err)
+	}
+	if len(lc.runs) != 0 {
+		t.Fatalf("runs = %v, want none with Submit", lc.runs)
+	}
+
+	got := decodeCheckResults(t, out)
+	if len(got) != 3 || got[2].Lang != "golang" || got[2].Status != "Wrong Answer" || got[2].SubmissionID == 0 || got[0].Runtime != "1 ms" {
+		t.Fatalf("results = %+v", got)
+	}
+	records, err := history.NewFileStore().List(context.Background(), filepath.Join(root, "two-sum"))
+	if err != nil || len(records) != 2 {
+		t.Fatalf("two-sum history = %+v (err=%v)", records, err)
+	}
+}
+
+func TestApp_CheckAll_RateLimitsPolls(t *testing.T) {
+	t.Parallel()
+
+	// Each run is judged on the second poll, so polls make up half of the requests.
+	var mu sync.Mutex
+	var times []time.Time
+	polled := map[string]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, time.Now())
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/graphql":
+			_, _ = w.Write([]byte(`{"data":{"question":{"questionId":"1","titleSlug":"p","exampleTestcases":"[1]\n1"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/interpret_solution/"):
+			_, _ = fmt.Fprintf(w, `{"interpret_id":"run_%d"}`, len(times))
+		case strings.HasSuffix(r.URL.Path, "/check/"):
+			if !polled[r.URL.Path] {
+				polled[r.URL.Path] = true
+				_, _ = w.Write([]byte(`{"state":"STARTED"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"state":"SUCCESS","status_msg":"Accepted","correct_answer":true,"total_correct":1,"total_testcases":1}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	root := t.TempDir()
+	for _, slug := range []string{"p1", "p2", "p3", "p4"} {
+		if err := os.MkdirAll(filepath.Join(root, slug), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, slug, "solution.cpp"), []byte("OK\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{LeetCode: config.LeetCodeAuth{Session: "sess", CSRFTOKEN: "csrf"}}},
+		LeetCode:    lcx.NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{BaseURL: ts.URL, UserAgent: "ua", Http: ts.Client()})),
+		Workspace:   workspace.NewFSManager(),
+		Output:      output.NewStdPrinter(&bytes.Buffer{}, &bytes.Buffer{}, true),
+	})
+
+	const rate = 5
+	if err := a.CheckAll(context.Background(), CheckAllOptions{Root: root, Concurrency: 4, Rate: rate}); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(times) != 16 {
+		t.Fatalf("requests = %d, want a fetch, a run and two polls per problem", len(times))
+	}
+	// The limiter may hold one token ahead, so any one-second window sees at most rate+1.
+	for i := range times {
+		n := 0
+		for _, tm := range times[i:] {
+			if tm.Sub(times[i]) < time.Second {
+				n++
+			}
+		}
+		if n > rate+1 {
+			t.Fatalf("%d requests within a second of request %d, want at most %d", n, i+1, rate+1)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vleet/internal/config"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+type FmtOptions struct {
+	ProblemKey string // MVP: titleSlug
+	Lang       string
+	File       string
+	Variant    string
+}
+
+// Fmt runs the configured formatter for the solution's language on the code below the
+// generated header and writes the result back.
+func (a *App) Fmt(ctx context.Context, opts FmtOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return err
+	}
+	ws, lang, err := a.existingWorkspace(ctx, cfg, problemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return err
+	}
+	content, err := a.Workspace.ReadSolution(ctx, ws)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := a.formatSolution(ctx, cfg, ws, lang, content)
+	if err != nil {
+		return err
+	}
+	if formatted == content {
+		a.humanf("already formatted: %s\n", ws.SolutionPath)
+	} else {
+		a.humanf("formatted: %s\n", ws.SolutionPath)
+	}
+	return nil
+}
+
+// formatSolution formats the code of a solution file with format.<lang> from the config
+// and writes it back when it changed. The generated header and the blank lines after it
+// are kept as is; only the code is piped through the formatter. It returns the new
+// content. A missing or failing formatter is an error, so nothing unformatted is
+// submitted by accident.
+func (a *App) formatSolution(ctx context.Context, cfg config.Config, ws workspace.Workspace, lang string, content string) (string, error) {
+	command := strings.TrimSpace(cfg.Format[lang])
+	if command == "" {
+		return "", fmt.Errorf("no formatter configured for %s (set format.%s in the config file)", lang, lang)
+	}
+	if a.Formatter == nil {
+		return "", fmt.Errorf("formatter is not configured")
+	}
+
+	header, body, _ := render.SplitHeader(lang, content)
+	code := strings.TrimLeft(body, "\r\n")
+	sep := body[:len(body)-len(code)]
+
+	formatted, err := a.Formatter.Format(ctx, command, code)
+	if err != nil {
+		return "", fmt.Errorf("format %s: %w (fix the code or the format.%s command; the file was left unchanged)", ws.SolutionPath, err, lang)
+	}
+
+	out := header + sep + formatted
+	if out == content {
+		return content, nil
+	}
+	if err := a.Workspace.ReplaceSolution(ctx, ws, out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
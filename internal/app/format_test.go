@@ -0,0 +1,99 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// fakeFormatter upper-cases code, or fails with err.
+type fakeFormatter struct {
+	got []string
+	err error
+}
+
+func (f *fakeFormatter) Format(ctx context.Context, command string, code string) (string, error) {
+	f.got = append(f.got, code)
+	if f.err != nil {
+		return "", f.err
+	}
+	return strings.ToUpper(code), nil
+}
+
+func TestApp_Fmt_FormatsCodeButNotHeader(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	solPath := filepath.Join(dir, "solution.cpp")
+	if err := os.WriteFile(solPath, []byte("// Two Sum (Easy)\n// keep me\n\nclass solution {};\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	f := &fakeFormatter{}
+	cfg := config.Config{Workspace: config.WorkspaceConfig{Root: root}}
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: cfg},
+		Workspace:   workspace.NewFSManager(),
+		Output:      output.NewStdPrinter(&bytes.Buffer{}, &bytes.Buffer{}, false),
+		Formatter:   f,
+	})
+
+	err := a.Fmt(context.Background(), FmtOptions{ProblemKey: "two-sum"})
+	if err == nil || !strings.Contains(err.Error(), "format.cpp") {
+		t.Fatalf("Fmt() without a formatter error = %v, want a hint to set format.cpp", err)
+	}
+
+	a.ConfigStore.(*fakeConfigStore).cfg.Format = map[string]string{"cpp": "clang-format"}
+	if err := a.Fmt(context.Background(), FmtOptions{ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Fmt() error = %v", err)
+	}
+	if len(f.got) != 1 || f.got[0] != "class solution {};\n" {
+		t.Fatalf("formatter input = %q, want only the code", f.got)
+	}
+	if b, _ := os.ReadFile(solPath); string(b) != "// Two Sum (Easy)\n// keep me\n\nCLASS SOLUTION {};\n" {
+		t.Fatalf("solution = %q, want the header untouched and the code formatted", b)
+	}
+}
+
+func TestApp_Submit_Format_FailingFormatterCancelsSubmit(t *testing.T) {
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted"},
+	}
+	a, dir, _ := newStatusTestApp(t, lc)
+	f := &fakeFormatter{err: errors.New("exit status 1:\nsolution.cpp:3: expected ';'")}
+	a.Formatter = f
+	a.ConfigStore.(*fakeConfigStore).cfg.Format = map[string]string{"cpp": "clang-format"}
+
+	err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp", Format: true})
+	if err == nil || !strings.Contains(err.Error(), "expected ';'") || !strings.Contains(err.Error(), "submit canceled") {
+		t.Fatalf("Submit(--format) error = %v, want the formatter error", err)
+	}
+	if recs, _ := history.NewFileStore().List(context.Background(), dir); len(recs) != 0 {
+		t.Fatalf("unformatted code was submitted: %+v", recs)
+	}
+
+	f.err = nil
+	a.Workspace.(*fakeWorkspaceManager).readSolution = "code\n"
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp", Format: true}); err != nil {
+		t.Fatalf("Submit(--format) error = %v", err)
+	}
+	if wm := a.Workspace.(*fakeWorkspaceManager); !wm.replaced || wm.wroteContent != "CODE\n" {
+		t.Fatalf("formatted solution not written back: %+v", wm)
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/gitx"
+)
+
+// autoCommit commits the workspace after an Accepted verdict when git.autocommit is set.
+// Workspaces outside a git repo (or without changes) are skipped silently, and git
+// failures are reported without failing the submit: the verdict is what matters.
+func (a *App) autoCommit(ctx context.Context, src source, r leetcode.SubmissionResult) {
+	if a.Git == nil || r.Status != kAcceptedStatus || !src.Config.Git.AutoCommit {
+		return
+	}
+
+	msg := commitMessage(src, r)
+	err := a.Git.Commit(ctx, src.Workspace.Dir, msg)
+	switch {
+	case err == nil:
+		a.humanf("committed: %s\n", msg)
+	case errors.Is(err, gitx.ErrNotRepo), errors.Is(err, gitx.ErrNothingToCommit):
+	default:
+		a.printError(ctx, fmt.Errorf("git autocommit: %w", err))
+	}
+}
+
+// commitMessage formats e.g. "two-sum (cpp): Accepted, 4 ms, 10.2 MB".
+func commitMessage(src source, r leetcode.SubmissionResult) string {
+	what := src.Lang
+	if src.Workspace.Variant != "" {
+		what += ", " + src.Workspace.Variant
+	}
+	parts := []string{r.Status}
+	for _, p := range []string{r.Runtime, r.Memory} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return fmt.Sprintf("%s (%s): %s", src.Workspace.ProblemKey, what, strings.Join(parts, ", "))
+}
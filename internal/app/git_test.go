@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/gitx"
+	"vleet/internal/output"
+)
+
+func TestApp_Submit_AutoCommitsAcceptedSolution(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Wrong Answer"},
+	}
+	a, dir, out := newStatusTestApp(t, lc)
+	a.Git = gitx.NewCLI()
+	a.ConfigStore.(*fakeConfigStore).cfg.Git.AutoCommit = true
+
+	repo := filepath.Dir(dir)
+	git(repo, "init", "--quiet")
+	git(repo, "config", "user.name", "vleet test")
+	git(repo, "config", "user.email", "vleet@example.com")
+	git(repo, "config", "commit.gpgsign", "false")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "solution.cpp"), []byte("CODE\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// Rejected: nothing is committed.
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if err := exec.Command("git", "-C", repo, "rev-parse", "--verify", "--quiet", "HEAD").Run(); err == nil {
+		t.Fatalf("expected no commit for a rejected submission")
+	}
+
+	lc.result = leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms", Memory: "10.2 MB"}
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if msg := git(repo, "log", "-1", "--format=%s"); msg != "two-sum (cpp): Accepted, 4 ms, 10.2 MB" {
+		t.Fatalf("commit message = %q", msg)
+	}
+	if files := git(repo, "show", "--name-only", "--format=", "HEAD"); !strings.Contains(files, "two-sum/solution.cpp") || !strings.Contains(files, "two-sum/.vleet/history.jsonl") {
+		t.Fatalf("committed files = %q, want the solution and its history", files)
+	}
+	if !strings.Contains(out.String(), "committed: two-sum (cpp)") {
+		t.Fatalf("expected a commit note, got:\n%s", out.String())
+	}
+}
+
+func TestApp_Submit_AutoCommit_SkipsOutsideRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted"},
+	}
+	a, dir, _ := newStatusTestApp(t, lc)
+	var out, stderr bytes.Buffer
+	a.Output = output.NewStdPrinter(&out, &stderr, false)
+	a.Git = gitx.NewCLI()
+	a.ConfigStore.(*fakeConfigStore).cfg = config.Config{
+		LeetCode: config.LeetCodeAuth{Session: "sess"},
+		Git:      config.GitConfig{AutoCommit: true},
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(filepath.Dir(dir)))
+
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if strings.Contains(out.String(), "committed") || stderr.Len() != 0 {
+		t.Fatalf("expected a silent skip, got stdout:\n%s\nstderr:\n%s", out.String(), stderr.String())
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/hooks"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// runHook runs a configured hook command in the workspace dir. The environment exposes
+// VLEET_HOOK, VLEET_SLUG, VLEET_LANG, VLEET_VARIANT, VLEET_WORKSPACE and VLEET_SOLUTION,
+// plus extra.
+func (a *App) runHook(ctx context.Context, name string, command string, ws workspace.Workspace, lang string, extra map[string]string, stdin []byte) error {
+	if a.Hooks == nil || strings.TrimSpace(command) == "" {
+		return nil
+	}
+
+	dir, solution := ws.Dir, ws.SolutionPath
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if abs, err := filepath.Abs(solution); err == nil {
+		solution = abs
+	}
+	env := map[string]string{
+		"VLEET_HOOK":      name,
+		"VLEET_SLUG":      ws.ProblemKey,
+		"VLEET_LANG":      lang,
+		"VLEET_VARIANT":   ws.Variant,
+		"VLEET_WORKSPACE": dir,
+		"VLEET_SOLUTION":  solution,
+	}
+	for k, v := range extra {
+		env[k] = v
+	}
+
+	return a.Hooks.Run(ctx, hooks.Hook{Name: name, Command: command, Dir: dir, Env: env, Stdin: stdin})
+}
+
+// postSubmitHook runs hooks.post_submit with the verdict JSON on stdin, in the same
+// vleet/v1 envelope as submit --json. Failures are
+// reported but don't change the outcome of the submit.
+func (a *App) postSubmitHook(ctx context.Context, src source, id leetcode.SubmissionID, r leetcode.SubmissionResult) {
+	cmd := src.Config.Hooks.PostSubmit
+	if a.Hooks == nil || strings.TrimSpace(cmd) == "" {
+		return
+	}
+
+	verdict := r.Status
+	if verdict == "" {
+		verdict = r.State
+	}
+	stdin, err := json.Marshal(output.Envelope{Schema: output.SchemaVersion, Kind: output.KindVerdict, Data: output.NewVerdict(r)})
+	if err != nil {
+		a.printError(ctx, err)
+		return
+	}
+	extra := map[string]string{
+		"VLEET_VERDICT":       verdict,
+		"VLEET_SUBMISSION_ID": strconv.FormatInt(int64(id), 10),
+	}
+	if err := a.runHook(ctx, hooks.PostSubmit, cmd, src.Workspace, src.Lang, extra, append(stdin, '\n')); err != nil {
+		a.printError(ctx, err)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/hooks"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// fakeHookRunner records hooks and fails the ones named in fail.
+type fakeHookRunner struct {
+	ran  []hooks.Hook
+	fail map[string]bool
+}
+
+func (r *fakeHookRunner) Run(ctx context.Context, h hooks.Hook) error {
+	r.ran = append(r.ran, h)
+	if r.fail[h.Name] {
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func TestApp_Submit_Hooks(t *testing.T) {
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted"},
+	}
+	a, dir, _ := newStatusTestApp(t, lc)
+	runner := &fakeHookRunner{fail: map[string]bool{hooks.PreSubmit: true}}
+	a.Hooks = runner
+	a.ConfigStore.(*fakeConfigStore).cfg.Hooks = config.HooksConfig{PreSubmit: "lint", PostSubmit: "notify"}
+
+	// A failing pre_submit hook vetoes the submit.
+	err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"})
+	if err == nil || !strings.Contains(err.Error(), "submit canceled") {
+		t.Fatalf("Submit() error = %v, want veto", err)
+	}
+	if recs, _ := history.NewFileStore().List(context.Background(), dir); len(recs) != 0 {
+		t.Fatalf("vetoed submit reached LeetCode: %+v", recs)
+	}
+
+	runner.fail = nil
+	runner.ran = nil
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if len(runner.ran) != 2 || runner.ran[0].Name != hooks.PreSubmit || runner.ran[1].Name != hooks.PostSubmit {
+		t.Fatalf("hooks = %+v, want pre_submit then post_submit", runner.ran)
+	}
+	post := runner.ran[1]
+	if post.Command != "notify" || post.Env["VLEET_SLUG"] != "two-sum" || post.Env["VLEET_LANG"] != "cpp" ||
+		post.Env["VLEET_VERDICT"] != "Accepted" || post.Env["VLEET_SUBMISSION_ID"] != "7" || !strings.HasSuffix(post.Env["VLEET_SOLUTION"], "solution.cpp") {
+		t.Fatalf("post_submit hook = %+v", post)
+	}
+	var env map[string]any
+	if err := json.Unmarshal(post.Stdin, &env); err != nil {
+		t.Fatalf("post_submit stdin = %s: %v", post.Stdin, err)
+	}
+	data, _ := env["data"].(map[string]any)
+	if env["schema"] != output.SchemaVersion || env["kind"] != output.KindVerdict || data["state"] != "SUCCESS" || data["status"] != "Accepted" {
+		t.Fatalf("post_submit stdin = %s, want the vleet/v1 verdict envelope", post.Stdin)
+	}
+}
+
+func TestApp_Fetch_RunsPostCreateHookForNewFilesOnly(t *testing.T) {
+	t.Parallel()
+
+	wm := &fakeWorkspaceManager{
+		ws: workspace.Workspace{
+			Dir:          "/tmp/two-sum",
+			ProblemKey:   "two-sum",
+			Lang:         "cpp",
+			SolutionPath: "/tmp/two-sum/solution.cpp",
+		},
+	}
+	runner := &fakeHookRunner{}
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{
+			DefaultLang: "cpp",
+			Hooks:       config.HooksConfig{PostCreate: "clang-format -i \"$VLEET_SOLUTION\""},
+		}},
+		LeetCode: &fakeLeetCodeClient{q: leetcode.Question{
+			TitleSlug:    "two-sum",
+			CodeSnippets: []leetcode.CodeSnippet{{LangSlug: "cpp", Code: "CODE"}},
+		}},
+		Workspace: wm,
+		Renderer:  &fakeRenderer{header: "HEADER"},
+		Output:    &fakeOutput{},
+		Hooks:     runner,
+	})
+
+	if err := a.Fetch(context.Background(), FetchOptions{ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(runner.ran) != 1 || runner.ran[0].Name != hooks.PostCreate || runner.ran[0].Env["VLEET_SOLUTION"] != "/tmp/two-sum/solution.cpp" {
+		t.Fatalf("hooks = %+v, want one post_create for the new solution", runner.ran)
+	}
+
+	wm.createErr = os.ErrExist
+	runner.ran = nil
+	if err := a.Fetch(context.Background(), FetchOptions{ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(runner.ran) != 0 {
+		t.Fatalf("post_create ran for an existing solution: %+v", runner.ran)
+	}
+}
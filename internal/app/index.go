@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"vleet/internal/history"
+	"vleet/internal/index"
+	"vleet/internal/workspace"
+)
+
+const kIndexCacheVersion = 1
+
+type IndexOptions struct {
+	// Root is the solutions directory to scan (default: workspace.root from config, else ".").
+	Root string
+}
+
+// Index scans root for workspaces (dirs with solution.<ext> files, at any
+// workspace.layout depth) and updates the solutions table in <root>/README.md.
+func (a *App) Index(ctx context.Context, opts IndexOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	root := strings.TrimSpace(opts.Root)
+	if root == "" {
+		cfg, err := a.loadConfigOrDefault(ctx)
+		if err != nil {
+			return err
+		}
+		if root, err = cfg.Workspace.RootDir(); err != nil {
+			return err
+		}
+	}
+
+	n, err := a.writeIndex(ctx, root, nil)
+	if err != nil {
+		return err
+	}
+	a.humanf("Indexed %d problem(s) into %s\n", n, filepath.Join(root, index.FileName))
+	return nil
+}
+
+// writeIndex rebuilds the index from the workspaces under root. Problem metadata comes
+// from <root>/.vleet/index.json, then known, and only then from LeetCode, so regenerating
+// the index only fetches problems it hasn't seen before. known also supplies the verdict
+// for workspaces without local history (e.g. written by sync).
+func (a *App) writeIndex(ctx context.Context, root string, known map[string]index.Entry) (int, error) {
+	located, err := workspace.ListWorkspaces(root)
+	if err != nil {
+		return 0, err
+	}
+
+	cache, err := loadIndexCache(root)
+	if err != nil {
+		return 0, err
+	}
+	dirty := false
+
+	var entries []index.Entry
+	for _, l := range located {
+		dir := filepath.Join(root, filepath.FromSlash(l.Dir))
+		files, err := workspace.SolutionFiles(dir)
+		if err != nil {
+			return 0, err
+		}
+
+		slug := l.Slug
+		e := index.Entry{Slug: slug, Dir: l.Dir}
+		for lang := range files {
+			e.Langs = append(e.Langs, lang)
+		}
+		sort.Strings(e.Langs)
+
+		meta, ok := cache.Problems[slug]
+		if !ok {
+			if k, found := known[slug]; found && k.Title != "" {
+				meta, ok = indexMeta{FrontendID: k.FrontendID, Title: k.Title, Difficulty: k.Difficulty, Tags: k.Tags}, true
+			} else if a.LeetCode != nil && ctx.Err() == nil {
+				if q, err := a.LeetCode.FetchQuestion(ctx, slug); err == nil {
+					meta, ok = indexMeta{FrontendID: q.FrontendID, Title: q.Title, Difficulty: q.Difficulty}, true
+					for _, t := range q.TopicTags {
+						meta.Tags = append(meta.Tags, t.Name)
+					}
+				} else {
+					a.printError(ctx, fmt.Errorf("index %s: %w", slug, err))
+				}
+			}
+			if ok {
+				cache.Problems[slug] = meta
+				dirty = true
+			}
+		}
+		e.FrontendID, e.Title, e.Difficulty, e.Tags = meta.FrontendID, meta.Title, meta.Difficulty, meta.Tags
+
+		if a.History != nil {
+			records, err := a.History.List(context.WithoutCancel(ctx), dir)
+			if err != nil {
+				return 0, err
+			}
+			for _, r := range records {
+				if r.State == history.StatePending || r.Time.Before(e.LastTime) {
+					continue
+				}
+				e.LastVerdict, e.LastTime = r.Status, r.Time
+				if e.LastVerdict == "" {
+					e.LastVerdict = r.State
+				}
+			}
+		}
+		if k, found := known[slug]; found && k.LastTime.After(e.LastTime) {
+			e.LastVerdict, e.LastTime = k.LastVerdict, k.LastTime
+		}
+
+		entries = append(entries, e)
+	}
+
+	if dirty {
+		if err := cache.save(root); err != nil {
+			return 0, err
+		}
+	}
+	if err := index.Write(context.WithoutCancel(ctx), root, entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// indexCache is persisted as <root>/.vleet/index.json.
+type indexCache struct {
+	Version  int                  `json:"version"`
+	Problems map[string]indexMeta `json:"problems"`
+}
+
+type indexMeta struct {
+	FrontendID string   `json:"frontend_id,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Difficulty string   `json:"difficulty,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+func indexCachePath(root string) string {
+	return filepath.Join(root, ".vleet", "index.json")
+}
+
+func loadIndexCache(root string) (*indexCache, error) {
+	p := indexCachePath(root)
+	c := &indexCache{Version: kIndexCacheVersion, Problems: map[string]indexMeta{}}
+
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("read index cache %s: %w", p, err)
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("parse index cache %s: %w", p, err)
+	}
+	if c.Problems == nil {
+		c.Problems = map[string]indexMeta{}
+	}
+	return c, nil
+}
+
+func (c *indexCache) save(root string) error {
+	p := indexCachePath(root)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("create index cache dir %s: %w", filepath.Dir(p), err)
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode index cache: %w", err)
+	}
+	if err := os.WriteFile(p, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write index cache %s: %w", p, err)
+	}
+	return nil
+}
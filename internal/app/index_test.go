@@ -0,0 +1,95 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/history"
+	"vleet/internal/output"
+)
+
+type fakeQuestionClient struct {
+	fakeLeetCodeClient
+	fetched []string
+}
+
+func (c *fakeQuestionClient) FetchQuestion(ctx context.Context, titleSlug string) (leetcode.Question, error) {
+	c.fetched = append(c.fetched, titleSlug)
+	return leetcode.Question{
+		TitleSlug:  titleSlug,
+		FrontendID: map[string]string{"two-sum": "1", "add-two-numbers": "2"}[titleSlug],
+		Title:      strings.ToUpper(titleSlug),
+		Difficulty: "Medium",
+		TopicTags:  []leetcode.TopicTag{{Name: "Math"}},
+	}, nil
+}
+
+func TestApp_Index_ScansWorkspaces_AndCachesMetadata(t *testing.T) {
+	root := t.TempDir()
+	for _, f := range []string{"two-sum/solution.cpp", "two-sum/solution.py", "add-two-numbers/solution.go", "notes/todo.txt"} {
+		p := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte("x\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	hist := history.NewFileStore()
+	when := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	for _, r := range []history.Record{
+		{Time: when.Add(-time.Hour), SubmissionID: 1, State: "SUCCESS", Status: "Wrong Answer"},
+		{Time: when, SubmissionID: 2, State: "SUCCESS", Status: "Accepted"},
+		{Time: when.Add(time.Hour), SubmissionID: 3, State: history.StatePending},
+	} {
+		if err := hist.Append(context.Background(), filepath.Join(root, "two-sum"), r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	lc := &fakeQuestionClient{}
+	a := New(App{
+		LeetCode: lc,
+		History:  hist,
+		Output:   output.NewStdPrinter(&bytes.Buffer{}, &bytes.Buffer{}, false),
+	})
+
+	if err := a.Index(context.Background(), IndexOptions{Root: root}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	got := string(b)
+	for _, want := range []string{
+		"| 1 | [TWO-SUM](two-sum/)",
+		"| Medium | Math | cpp, python3 | Accepted | 2026-05-06 |",
+		"| 2 | [ADD-TWO-NUMBERS](add-two-numbers/)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("index missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "notes") {
+		t.Fatalf("non-workspace dir indexed:\n%s", got)
+	}
+	if len(lc.fetched) != 2 {
+		t.Fatalf("fetched %v, want both problems once", lc.fetched)
+	}
+
+	// Regenerating uses the metadata cache.
+	lc.fetched = nil
+	if err := a.Index(context.Background(), IndexOptions{Root: root}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if len(lc.fetched) != 0 {
+		t.Fatalf("second Index() fetched %v, want none", lc.fetched)
+	}
+}
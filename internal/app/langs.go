@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"vleet/internal/errx"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+type LangsOptions struct {
+	ProblemKey string // MVP: titleSlug
+}
+
+// Langs lists the languages with a solution file in a problem's workspace, with the
+// last verdict recorded for each in the workspace history.
+func (a *App) Langs(ctx context.Context, opts LangsOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return err
+	}
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return err
+	}
+	dir, ok := workspace.FindDir(root, problemKey)
+	if !ok {
+		return errNoWorkspace(root, problemKey)
+	}
+
+	files, err := workspace.LangFiles(dir)
+	if err != nil {
+		return err
+	}
+	byLang := make(map[string]*output.LangStatus, len(files))
+	langs := make([]output.LangStatus, 0, len(files))
+	for lang, paths := range files {
+		l := output.LangStatus{Lang: lang}
+		for _, p := range paths {
+			l.Files = append(l.Files, filepath.Base(p))
+		}
+		langs = append(langs, l)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i].Lang < langs[j].Lang })
+	for i := range langs {
+		byLang[langs[i].Lang] = &langs[i]
+	}
+
+	if a.History != nil {
+		records, err := a.History.List(ctx, dir)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			l, ok := byLang[r.Lang]
+			if !ok || r.State == history.StatePending || r.Time.Before(l.LastTime) {
+				continue
+			}
+			l.LastVerdict, l.LastTime = r.Status, r.Time
+			if l.LastVerdict == "" {
+				l.LastVerdict = r.State
+			}
+		}
+	}
+
+	if a.Output != nil {
+		return a.Output.PrintLangs(ctx, langs)
+	}
+	return nil
+}
+
+// inferLang picks the language for a command run without --lang: the extension of an
+// explicit file, else the only language with a solution file (or the requested variant)
+// in the problem's workspace. It returns "" when nothing can be inferred, so callers
+// fall back to default_lang, and an error listing the choices when several match.
+func inferLang(root string, problemKey string, file string, variant string) (string, error) {
+	if f := strings.TrimSpace(file); f != "" {
+		lang, _ := workspace.LangForExtension(filepath.Ext(f))
+		return lang, nil
+	}
+
+	dir, ok := workspace.FindDir(root, problemKey)
+	if !ok {
+		return "", nil
+	}
+	files, err := workspace.LangFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	variant = strings.TrimSpace(variant)
+	var candidates []string
+	for lang, paths := range files {
+		for _, p := range paths {
+			if variant == "" || workspace.VariantOf(p) == variant {
+				candidates = append(candidates, lang)
+				break
+			}
+		}
+	}
+	sort.Strings(candidates)
+
+	switch len(candidates) {
+	case 0:
+		return "", nil
+	case 1:
+		return candidates[0], nil
+	}
+	return "", errx.Mark(
+		fmt.Errorf("%s has solutions in several languages (%s); pick one with --lang", problemKey, strings.Join(candidates, ", ")),
+		errx.ErrWorkspaceConflict,
+	)
+}
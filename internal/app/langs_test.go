@@ -0,0 +1,106 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+func writeSolutionFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, n := range names {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte("x\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", n, err)
+		}
+	}
+}
+
+func TestInferLang(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+
+	if lang, err := inferLang(root, "two-sum", "", ""); err != nil || lang != "" {
+		t.Fatalf("inferLang(no workspace) = (%q, %v), want empty", lang, err)
+	}
+
+	writeSolutionFiles(t, dir, "solution.cpp")
+	if lang, err := inferLang(root, "two-sum", "", ""); err != nil || lang != "cpp" {
+		t.Fatalf("inferLang(cpp only) = (%q, %v), want cpp", lang, err)
+	}
+
+	writeSolutionFiles(t, dir, "solution.py", "solution.dp.py")
+	_, err := inferLang(root, "two-sum", "", "")
+	if err == nil || !strings.Contains(err.Error(), "cpp, python3") {
+		t.Fatalf("inferLang(cpp+python3) error = %v, want the choices listed", err)
+	}
+	if lang, err := inferLang(root, "two-sum", "", "dp"); err != nil || lang != "python3" {
+		t.Fatalf("inferLang(--variant dp) = (%q, %v), want python3", lang, err)
+	}
+	if lang, err := inferLang(root, "two-sum", "other.cpp", ""); err != nil || lang != "cpp" {
+		t.Fatalf("inferLang(--file other.cpp) = (%q, %v), want cpp", lang, err)
+	}
+}
+
+func TestApp_Langs_ListsLanguagesWithLastVerdict(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+	writeSolutionFiles(t, dir, "solution.cpp", "solution.greedy.cpp", "solution.py")
+
+	store := history.NewFileStore()
+	now := time.Now().UTC()
+	for _, r := range []history.Record{
+		{Time: now.Add(-2 * time.Hour), Lang: "cpp", State: "SUCCESS", Status: "Wrong Answer"},
+		{Time: now.Add(-time.Hour), Lang: "cpp", State: "SUCCESS", Status: "Accepted"},
+		{Time: now, Lang: "cpp", State: history.StatePending},
+	} {
+		if err := store.Append(context.Background(), dir, r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{Workspace: config.WorkspaceConfig{Root: root}}},
+		Workspace:   workspace.NewFSManager(),
+		Output:      output.NewStdPrinter(&out, &bytes.Buffer{}, true),
+		History:     store,
+	})
+	if err := a.Langs(context.Background(), LangsOptions{ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Langs() error = %v", err)
+	}
+
+	var env struct {
+		Kind string              `json:"kind"`
+		Data []output.LangStatus `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &env); err != nil || env.Kind != output.KindLangs {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	got := env.Data
+	if len(got) != 2 || got[0].Lang != "cpp" || got[1].Lang != "python3" {
+		t.Fatalf("langs = %+v, want cpp and python3", got)
+	}
+	if strings.Join(got[0].Files, ",") != "solution.cpp,solution.greedy.cpp" || got[0].LastVerdict != "Accepted" {
+		t.Fatalf("cpp = %+v, want both files and the last non-pending verdict", got[0])
+	}
+	if got[1].LastVerdict != "" {
+		t.Fatalf("python3 = %+v, want no verdict", got[1])
+	}
+}
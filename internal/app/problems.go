@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"vleet/internal/history"
+	"vleet/internal/workspace"
+)
+
+// Problem is a workspace found under the workspace root.
+type Problem struct {
+	Slug string `json:"slug"`
+
+	// Dir is the workspace dir relative to the root.
+	Dir   string   `json:"dir"`
+	Langs []string `json:"langs"`
+}
+
+// ListProblems returns the workspaces under workspace.root (see workspace.ListWorkspaces).
+func (a *App) ListProblems(ctx context.Context) ([]Problem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	located, err := workspace.ListWorkspaces(root)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Problem, 0, len(located))
+	for _, l := range located {
+		files, err := workspace.SolutionFiles(filepath.Join(root, filepath.FromSlash(l.Dir)))
+		if err != nil {
+			return nil, err
+		}
+		p := Problem{Slug: l.Slug, Dir: l.Dir, Langs: []string{}}
+		for lang := range files {
+			p.Langs = append(p.Langs, lang)
+		}
+		sort.Strings(p.Langs)
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Slug < out[j].Slug })
+	return out, nil
+}
+
+// ProblemHistory returns the submission history recorded in a problem's workspace,
+// oldest first.
+func (a *App) ProblemHistory(ctx context.Context, problemKey string) ([]history.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if a.History == nil {
+		return nil, fmt.Errorf("history store is not configured")
+	}
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return nil, err
+	}
+	dir, ok := workspace.FindDir(root, problemKey)
+	if !ok {
+		return nil, errNoWorkspace(root, problemKey)
+	}
+	records, err := a.History.List(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []history.Record{}
+	}
+	return records, nil
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
+	"vleet/internal/workspace"
+)
+
+const (
+	kAcceptedStatus = "Accepted"
+
+	// kPullSearchLimit bounds how far back pull looks for an accepted submission.
+	kPullSearchLimit = 200
+)
+
+type PullOptions struct {
+	ProblemKey string // MVP: titleSlug
+
+	// Lang selects the language; "" takes the newest accepted submission in any language
+	// vleet supports.
+	Lang string
+
+	// Force overwrites an existing solution file.
+	Force bool
+}
+
+// Pull writes the latest accepted submission's code for a problem into its workspace,
+// below the usual rendered header.
+func (a *App) Pull(ctx context.Context, opts PullOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+	lister, ok := a.LeetCode.(lcx.SubmissionLister)
+	if !ok {
+		return fmt.Errorf("leetcode client does not support listing submissions")
+	}
+	fetcher, ok := a.LeetCode.(lcx.SubmissionFetcher)
+	if !ok {
+		return fmt.Errorf("leetcode client does not support fetching submissions")
+	}
+	if a.Renderer == nil {
+		return fmt.Errorf("renderer is not configured")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
+		return errNoSession
+	}
+	a.injectAuth(cfg)
+
+	lang := strings.TrimSpace(opts.Lang)
+
+	// Submissions are listed newest first.
+	subs, err := lister.ListSubmissions(ctx, problemKey, lcx.ListSubmissionsOptions{Limit: kPullSearchLimit})
+	if err != nil {
+		return err
+	}
+	var accepted *lcx.Submission
+	for i := range subs {
+		if subs[i].Status != kAcceptedStatus {
+			continue
+		}
+		if (lang == "" && workspace.IsSupportedLang(subs[i].Lang)) || subs[i].Lang == lang {
+			accepted = &subs[i]
+			break
+		}
+	}
+	if accepted == nil {
+		if lang == "" {
+			return errx.Mark(fmt.Errorf("no accepted submission found for %s", problemKey), errx.ErrNotFound)
+		}
+		return errx.Mark(fmt.Errorf("no accepted %s submission found for %s", lang, problemKey), errx.ErrNotFound)
+	}
+	lang = accepted.Lang
+
+	detail, err := fetcher.FetchSubmission(ctx, accepted.ID)
+	if err != nil {
+		return err
+	}
+
+	q, err := a.LeetCode.FetchQuestion(ctx, problemKey)
+	if err != nil {
+		return err
+	}
+	header, err := a.Renderer.RenderHeader(ctx, lang, q)
+	if err != nil {
+		return err
+	}
+	content := header + "\n" + detail.Code
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return err
+	}
+	ws, err := a.Workspace.CreateWorkspace(ctx, root, q, lang, workspace.CreateOptions{Layout: cfg.Workspace.Layout})
+	switch {
+	case err == nil:
+		err = a.Workspace.WriteSolution(ctx, ws, content)
+	case errors.Is(err, os.ErrExist) && opts.Force:
+		ws, err = a.Workspace.LoadWorkspace(ctx, root, q.TitleSlug, lang, "")
+		if err == nil {
+			err = a.Workspace.ReplaceSolution(ctx, ws, content)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w (use --force to overwrite)", err)
+		}
+		return err
+	}
+
+	a.humanf("Pulled accepted submission %d (%s) into %s\n", accepted.ID, lang, ws.SolutionPath)
+	return nil
+}
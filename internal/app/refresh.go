@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+type RefreshOptions struct {
+	ProblemKey string // MVP: titleSlug
+
+	// Lang, File and Variant select a single solution file. When all are empty, every
+	// solution file in the workspace is refreshed.
+	Lang    string
+	File    string
+	Variant string
+}
+
+// Refresh re-renders the generated header of existing solution files from the current
+// problem statement. Only the header block (see render.SplitHeader) is replaced, and only
+// when vleet generated it (render.IsGeneratedHeader); the code below it is kept byte for
+// byte.
+func (a *App) Refresh(ctx context.Context, opts RefreshOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+	if a.Renderer == nil {
+		return fmt.Errorf("renderer is not configured")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return err
+	}
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return err
+	}
+
+	type target struct{ lang, file string }
+	var targets []target
+	if strings.TrimSpace(opts.Lang) == "" && strings.TrimSpace(opts.File) == "" && strings.TrimSpace(opts.Variant) == "" {
+		dir, ok := workspace.FindDir(root, problemKey)
+		if !ok {
+			return errNoWorkspace(root, problemKey)
+		}
+		files, err := workspace.LangFiles(dir)
+		if err != nil {
+			return err
+		}
+		for lang, paths := range files {
+			for _, p := range paths {
+				targets = append(targets, target{lang: lang, file: filepath.Base(p)})
+			}
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("no solution files in %s", dir)
+		}
+		sort.Slice(targets, func(i, j int) bool { return targets[i].file < targets[j].file })
+	} else {
+		lang, err := a.sourceLang(cfg, root, problemKey, opts.Lang, opts.File, opts.Variant)
+		if err != nil {
+			return err
+		}
+		file, err := solutionFileOption(lang, opts.File, opts.Variant)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target{lang: lang, file: file})
+	}
+
+	q, err := a.LeetCode.FetchQuestion(ctx, problemKey)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, t := range targets {
+		if err := a.refreshFile(ctx, root, q, t.lang, t.file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *App) refreshFile(ctx context.Context, root string, q leetcode.Question, lang string, file string) error {
+	ws, err := a.Workspace.LoadWorkspace(ctx, root, q.TitleSlug, lang, file)
+	if err != nil {
+		return err
+	}
+	content, err := a.Workspace.ReadSolution(ctx, ws)
+	if err != nil {
+		return err
+	}
+	oldHeader, body, ok := render.SplitHeader(lang, content)
+	if !ok || !render.IsGeneratedHeader(lang, q.TitleSlug, oldHeader) {
+		return fmt.Errorf("%s has no generated header to refresh (the title and URL comment lines, then an empty line)", ws.SolutionPath)
+	}
+
+	header, err := a.Renderer.RenderHeader(ctx, lang, q)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(header, "\n") {
+		header += "\n"
+	}
+	if header == oldHeader {
+		a.humanf("header already up to date: %s\n", ws.SolutionPath)
+		return nil
+	}
+
+	if err := a.Workspace.ReplaceSolution(ctx, ws, header+body); err != nil {
+		return err
+	}
+	a.humanf("refreshed header: %s\n", ws.SolutionPath)
+	return nil
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/output"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+func TestApp_Refresh_ReplacesHeaderAndKeepsCode(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// Code with trailing spaces, a CRLF line and no final newline must survive as is.
+	code := "class Solution {  \r\n// not part of the header\n};"
+	cppPath := filepath.Join(dir, "solution.cpp")
+	if err := os.WriteFile(cppPath, []byte("// Two Sum (Easy)\n// URL: https://leetcode.com/problems/two-sum/\n// old statement\n\n"+code), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	pyPath := filepath.Join(dir, "solution.dp.py")
+	if err := os.WriteFile(pyPath, []byte("# Two Sum (Easy)\n# URL: https://leetcode.com/problems/two-sum/\n\n# mine\npass\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	lc := &fakeLeetCodeClient{q: leetcode.Question{
+		TitleSlug:   "two-sum",
+		Title:       "Two Sum",
+		Difficulty:  "Easy",
+		ContentHTML: "<p>new statement</p>",
+	}}
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{Workspace: config.WorkspaceConfig{Root: root}}},
+		LeetCode:    lc,
+		Workspace:   workspace.NewFSManager(),
+		Renderer:    render.NewHTMLRenderer(),
+		Output:      output.NewStdPrinter(&bytes.Buffer{}, &bytes.Buffer{}, false),
+	})
+
+	if err := a.Refresh(context.Background(), RefreshOptions{ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	b, _ := os.ReadFile(cppPath)
+	header, body, ok := render.SplitHeader("cpp", string(b))
+	if !ok || !strings.Contains(header, "// new statement") || strings.Contains(header, "old statement") {
+		t.Fatalf("cpp header not refreshed:\n%s", b)
+	}
+	if body != "\n"+code {
+		t.Fatalf("cpp body = %q, want %q", body, "\n"+code)
+	}
+	if b, _ := os.ReadFile(pyPath); !strings.Contains(string(b), "# new statement") || !strings.HasSuffix(string(b), "\n\n# mine\npass\n") {
+		t.Fatalf("variant not refreshed or code changed:\n%s", b)
+	}
+
+	// A file without a generated header is left alone and reported.
+	if err := os.WriteFile(cppPath, []byte(code), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err := a.Refresh(context.Background(), RefreshOptions{ProblemKey: "two-sum", Lang: "cpp"})
+	if err == nil || !strings.Contains(err.Error(), "no generated header") {
+		t.Fatalf("Refresh() error = %v, want missing header", err)
+	}
+	if b, _ := os.ReadFile(cppPath); string(b) != code {
+		t.Fatalf("file without header changed:\n%s", b)
+	}
+
+	// Neither is a leading comment the user wrote.
+	own := "// Copyright 2026 me\n// My notes on two-sum\n\n" + code
+	if err := os.WriteFile(cppPath, []byte(own), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err = a.Refresh(context.Background(), RefreshOptions{ProblemKey: "two-sum", Lang: "cpp"})
+	if err == nil || !strings.Contains(err.Error(), "no generated header") {
+		t.Fatalf("Refresh() error = %v, want missing header", err)
+	}
+	if b, _ := os.ReadFile(cppPath); string(b) != own {
+		t.Fatalf("hand-written comment changed:\n%s", b)
+	}
+
+	// Nor is a generated header whose blank separator was deleted: the notes below it
+	// would be taken for part of it.
+	joined := "// Two Sum (Easy)\n// URL: https://leetcode.com/problems/two-sum/\n// old statement\n// my notes\n" + code
+	if err := os.WriteFile(cppPath, []byte(joined), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err = a.Refresh(context.Background(), RefreshOptions{ProblemKey: "two-sum", Lang: "cpp"})
+	if err == nil || !strings.Contains(err.Error(), "no generated header") {
+		t.Fatalf("Refresh() error = %v, want missing header", err)
+	}
+	if b, _ := os.ReadFile(cppPath); string(b) != joined {
+		t.Fatalf("file without a header separator changed:\n%s", b)
+	}
+}
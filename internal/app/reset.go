@@ -0,0 +1,102 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"vleet/internal/output"
+)
+
+type ResetOptions struct {
+	ProblemKey string // MVP: titleSlug
+	Lang       string
+	File       string
+	Variant    string
+
+	// Yes skips the confirmation prompt. Otherwise the answer is read from Confirm
+	// (typically stdin); without Confirm, Reset refuses to run.
+	Yes     bool
+	Confirm io.Reader
+}
+
+// Reset starts a solution over: the current file is moved to <workspace>/attempts/ and a
+// fresh header + starter snippet is written in its place, as fetch would.
+func (a *App) Reset(ctx context.Context, opts ResetOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+	if a.Renderer == nil {
+		return fmt.Errorf("renderer is not configured")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return err
+	}
+	ws, lang, err := a.existingWorkspace(ctx, cfg, problemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		ok, err := a.confirm(opts.Confirm, fmt.Sprintf("Reset %s to the starter code? The current file is moved to attempts/. [y/N] ", ws.SolutionPath))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("reset canceled")
+		}
+	}
+
+	// Render before archiving so a failed fetch leaves the solution where it was.
+	_, _, content, err := a.starterSolution(ctx, problemKey, lang)
+	if err != nil {
+		return err
+	}
+
+	archived, err := a.Workspace.ArchiveSolution(ctx, ws)
+	if err != nil {
+		return err
+	}
+	if err := a.Workspace.WriteSolution(ctx, ws, content); err != nil {
+		return fmt.Errorf("%w (previous solution kept at %s)", err, archived)
+	}
+
+	a.humanf("moved previous solution to %s\n", archived)
+	a.humanf("wrote solution: %s\n", ws.SolutionPath)
+	return nil
+}
+
+// confirm prints prompt to stderr and reads a yes/no answer from r. Anything but
+// "y"/"yes" (including EOF) is a no.
+func (a *App) confirm(r io.Reader, prompt string) (bool, error) {
+	if r == nil {
+		return false, fmt.Errorf("confirmation required; pass --yes to run non-interactively")
+	}
+	if sp, ok := a.Output.(*output.StdPrinter); ok {
+		_, _ = fmt.Fprint(sp.Err, prompt)
+	}
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
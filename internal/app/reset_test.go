@@ -0,0 +1,81 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/output"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+func TestApp_Reset_ArchivesAndRewritesStarter(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	solPath := filepath.Join(dir, "solution.cpp")
+	if err := os.WriteFile(solPath, []byte("HOPELESS\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var stderr bytes.Buffer
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{Workspace: config.WorkspaceConfig{Root: root}}},
+		LeetCode: &fakeLeetCodeClient{q: leetcode.Question{
+			TitleSlug:    "two-sum",
+			Title:        "Two Sum",
+			CodeSnippets: []leetcode.CodeSnippet{{LangSlug: "cpp", Code: "class Solution {};"}},
+		}},
+		Workspace: workspace.NewFSManager(),
+		Renderer:  render.NewHTMLRenderer(),
+		Output:    output.NewStdPrinter(&bytes.Buffer{}, &stderr, false),
+	})
+	opts := ResetOptions{ProblemKey: "two-sum"}
+
+	if err := a.Reset(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("Reset(no confirm input) error = %v, want --yes hint", err)
+	}
+	opts.Confirm = strings.NewReader("n\n")
+	if err := a.Reset(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Fatalf("Reset(n) error = %v, want canceled", err)
+	}
+	if b, _ := os.ReadFile(solPath); string(b) != "HOPELESS\n" {
+		t.Fatalf("solution changed without confirmation:\n%s", b)
+	}
+	if !strings.Contains(stderr.String(), "[y/N]") {
+		t.Fatalf("expected a confirmation prompt on stderr, got %q", stderr.String())
+	}
+
+	opts.Confirm = strings.NewReader("y\n")
+	if err := a.Reset(context.Background(), opts); err != nil {
+		t.Fatalf("Reset(y) error = %v", err)
+	}
+	if b, _ := os.ReadFile(solPath); !strings.HasPrefix(string(b), "// Two Sum") || !strings.HasSuffix(string(b), "\nclass Solution {};\n") {
+		t.Fatalf("solution not reset to the starter:\n%s", b)
+	}
+	archived, _ := filepath.Glob(filepath.Join(dir, "attempts", "solution.*.cpp"))
+	if len(archived) != 1 {
+		t.Fatalf("attempts = %v, want one archived solution", archived)
+	}
+	if b, _ := os.ReadFile(archived[0]); string(b) != "HOPELESS\n" {
+		t.Fatalf("archived solution = %q, want the previous code", b)
+	}
+
+	// --yes skips the prompt; a second reset in the same second gets its own archive.
+	if err := a.Reset(context.Background(), ResetOptions{ProblemKey: "two-sum", Yes: true}); err != nil {
+		t.Fatalf("Reset(--yes) error = %v", err)
+	}
+	if archived, _ := filepath.Glob(filepath.Join(dir, "attempts", "solution.*.cpp")); len(archived) != 2 {
+		t.Fatalf("attempts = %v, want two archived solutions", archived)
+	}
+}
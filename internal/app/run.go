@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/lcx"
+)
+
+type RunOptions struct {
+	ProblemKey string
+	Lang       string
+	File       string // optional override; defaults to ./<problem-key>/solution.<ext>
+	Variant    string // optional named variant; mutually exclusive with File
+}
+
+// Run runs the workspace solution against the problem's example testcases on LeetCode
+// ("Run" in the web editor). Unlike Submit, this does not create a submission.
+func (a *App) Run(ctx context.Context, opts RunOptions) error {
+	result, err := a.runExamples(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if a.Output != nil {
+		if err := a.Output.PrintRunResult(ctx, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *App) runExamples(ctx context.Context, opts RunOptions) (lcx.RunResult, error) {
+	runner, ok := a.LeetCode.(lcx.Runner)
+	if !ok {
+		return lcx.RunResult{}, fmt.Errorf("leetcode client does not support running code")
+	}
+
+	src, err := a.loadSource(ctx, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return lcx.RunResult{}, err
+	}
+
+	a.setOutputSource(src)
+
+	input, err := exampleInput(src.Question)
+	if err != nil {
+		return lcx.RunResult{}, err
+	}
+
+	runID, err := runner.RunCode(ctx, lcx.RunRequest{
+		TitleSlug:  opts.ProblemKey,
+		QuestionID: src.Question.QuestionID,
+		Lang:       src.Lang,
+		TypedCode:  src.Code,
+		DataInput:  input,
+	})
+	if err != nil {
+		return lcx.RunResult{}, err
+	}
+
+	result, err := runner.PollRun(ctx, runID, leetcode.PollOptions{})
+	if err != nil {
+		return lcx.RunResult{}, err
+	}
+	for _, r := range a.Reports {
+		r.AddRun(opts.ProblemKey, src.Lang, input, result)
+	}
+	return result, nil
+}
+
+// exampleInput is the testcase input a run sends: the problem's examples, else its sample.
+func exampleInput(q leetcode.Question) (string, error) {
+	input := q.ExampleTestcases
+	if strings.TrimSpace(input) == "" {
+		input = q.SampleTestCase
+	}
+	if strings.TrimSpace(input) == "" {
+		return "", fmt.Errorf("no example testcases available for problem %s", q.TitleSlug)
+	}
+	return input, nil
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vleet/internal/workspace"
+)
+
+// CreateWorkspace creates the workspace and starter solution for a problem like Fetch,
+// but returns the result instead of printing it (for the MCP adapter). created is false
+// when the solution file already existed; it is never overwritten.
+func (a *App) CreateWorkspace(ctx context.Context, opts FetchOptions) (ws workspace.Workspace, created bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return workspace.Workspace{}, false, err
+	}
+	if strings.TrimSpace(opts.ProblemKey) == "" {
+		return workspace.Workspace{}, false, fmt.Errorf("problem key (titleSlug) is required")
+	}
+
+	prep, err := a.prepareSolutionFile(ctx, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return workspace.Workspace{}, false, err
+	}
+	return prep.Workspace, prep.CreatedNewFile, nil
+}
+
+type ReadSolutionOptions struct {
+	ProblemKey string
+	Lang       string
+	File       string
+	Variant    string
+}
+
+// ReadSolution returns the workspace and current content of an existing solution file.
+func (a *App) ReadSolution(ctx context.Context, opts ReadSolutionOptions) (workspace.Workspace, string, error) {
+	if err := ctx.Err(); err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	if strings.TrimSpace(opts.ProblemKey) == "" {
+		return workspace.Workspace{}, "", fmt.Errorf("problem key (titleSlug) is required")
+	}
+	if a.Workspace == nil {
+		return workspace.Workspace{}, "", fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	ws, _, err := a.existingWorkspace(ctx, cfg, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	code, err := a.Workspace.ReadSolution(ctx, ws)
+	if err != nil {
+		return workspace.Workspace{}, "", err
+	}
+	return ws, code, nil
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/history"
+)
+
+type StatusOptions struct {
+	SubmissionID leetcode.SubmissionID
+
+	// ProblemKey optionally names the workspace whose history should record the verdict
+	// (e.g. to resolve a PENDING record left by an interrupted submit).
+	ProblemKey string
+	Lang       string
+}
+
+// Status polls an existing submission until it reaches a final state and prints it.
+func (a *App) Status(ctx context.Context, opts StatusOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if opts.SubmissionID <= 0 {
+		return fmt.Errorf("submission id is required")
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+
+	cfg, err := a.loadConfigRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
+		return errNoSession
+	}
+	a.injectAuth(cfg)
+
+	result, err := a.LeetCode.PollSubmission(ctx, opts.SubmissionID, leetcode.PollOptions{})
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(opts.ProblemKey) != "" {
+		if err := a.resolvePending(ctx, cfg, opts, result); err != nil {
+			a.printError(ctx, err)
+		}
+	}
+
+	if a.Output != nil {
+		if err := a.Output.PrintSubmissionResult(ctx, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePending appends the final verdict to the workspace history, carrying over the
+// language/file from the PENDING record when there is one.
+func (a *App) resolvePending(ctx context.Context, cfg config.Config, opts StatusOptions, result leetcode.SubmissionResult) error {
+	if a.History == nil || a.Workspace == nil {
+		return nil
+	}
+
+	lang := strings.TrimSpace(opts.Lang)
+	if lang == "" {
+		lang = strings.TrimSpace(cfg.DefaultLang)
+	}
+	if lang == "" {
+		lang = kDefaultLang
+	}
+
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return err
+	}
+	ws, err := a.Workspace.LoadWorkspace(ctx, root, opts.ProblemKey, lang, "")
+	if err != nil {
+		return err
+	}
+
+	records, err := a.History.List(ctx, ws.Dir)
+	if err != nil {
+		return err
+	}
+
+	file := ""
+	for _, r := range records {
+		if r.SubmissionID == int64(opts.SubmissionID) && r.State == history.StatePending {
+			lang, file = r.Lang, r.File
+		}
+	}
+	return a.History.Append(ctx, ws.Dir, newHistoryRecord(ws.ProblemKey, lang, file, opts.SubmissionID, result))
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// fakeSubmitClient submits successfully; PollSubmission returns result, or blocks until
+// ctx is canceled when block is set.
+type fakeSubmitClient struct {
+	fakeLeetCodeClient
+
+	id      leetcode.SubmissionID
+	result  leetcode.SubmissionResult
+	block   bool
+	polling chan struct{}
+}
+
+func (c *fakeSubmitClient) Submit(ctx context.Context, req leetcode.SubmitRequest) (leetcode.SubmissionID, error) {
+	return c.id, nil
+}
+
+func (c *fakeSubmitClient) PollSubmission(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions) (leetcode.SubmissionResult, error) {
+	if c.block {
+		if c.polling != nil {
+			close(c.polling)
+		}
+		<-ctx.Done()
+		return leetcode.SubmissionResult{}, ctx.Err()
+	}
+	return c.result, nil
+}
+
+func newStatusTestApp(t *testing.T, lc leetcode.Client) (*App, string, *bytes.Buffer) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "two-sum")
+	var out bytes.Buffer
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{DefaultLang: "cpp", LeetCode: config.LeetCodeAuth{Session: "sess"}}},
+		LeetCode:    lc,
+		Workspace: &fakeWorkspaceManager{
+			ws: workspace.Workspace{
+				Dir:          dir,
+				ProblemKey:   "two-sum",
+				Lang:         "cpp",
+				SolutionPath: filepath.Join(dir, "solution.cpp"),
+			},
+			readSolution: "CODE\n",
+		},
+		Output:  output.NewStdPrinter(&out, &bytes.Buffer{}, false),
+		History: history.NewFileStore(),
+	})
+	return a, dir, &out
+}
+
+func TestApp_Submit_InterruptedPoll_RecordsPendingAndResumes(t *testing.T) {
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 123,
+		block:              true,
+		polling:            make(chan struct{}),
+	}
+	a, dir, out := newStatusTestApp(t, lc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-lc.polling
+		cancel() // simulate Ctrl-C while waiting for the verdict
+	}()
+
+	err := a.Submit(ctx, SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"})
+
+	var pending *PendingSubmissionError
+	if !errors.As(err, &pending) {
+		t.Fatalf("Submit() error = %v, want *PendingSubmissionError", err)
+	}
+	if pending.SubmissionID != 123 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("pending = %+v, want submission 123 wrapping context.Canceled", pending)
+	}
+	if !strings.Contains(err.Error(), "vleet status --problem two-sum 123") {
+		t.Fatalf("error should tell how to resume, got: %v", err)
+	}
+
+	recs, err := history.NewFileStore().List(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(recs) != 1 || recs[0].SubmissionID != 123 || recs[0].State != history.StatePending || recs[0].File != "solution.cpp" {
+		t.Fatalf("history = %+v, want one pending record for 123", recs)
+	}
+
+	// Resume: status polls the same ID and resolves the pending record.
+	lc.block = false
+	lc.result = leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms"}
+	if err := a.Status(context.Background(), StatusOptions{SubmissionID: 123, ProblemKey: "two-sum"}); err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Verdict: Accepted") {
+		t.Fatalf("expected verdict in output, got:\n%s", out.String())
+	}
+
+	recs, _ = history.NewFileStore().List(context.Background(), dir)
+	latest := history.Latest(recs)
+	if len(latest) != 1 || latest[0].Status != "Accepted" || latest[0].File != "solution.cpp" {
+		t.Fatalf("latest history = %+v, want submission 123 resolved to Accepted", latest)
+	}
+}
+
+func TestApp_Submit_RecordsVerdictInHistory(t *testing.T) {
+	lc := &fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Wrong Answer"},
+	}
+	a, dir, _ := newStatusTestApp(t, lc)
+
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	recs, err := history.NewFileStore().List(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(recs) != 1 || recs[0].SubmissionID != 7 || recs[0].Status != "Wrong Answer" || recs[0].Lang != "cpp" {
+		t.Fatalf("history = %+v", recs)
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vleet/internal/lcx"
+)
+
+type SubmissionsOptions struct {
+	ProblemKey string // MVP: titleSlug
+
+	// Limit is the maximum number of submissions listed, newest first (default: 20).
+	Limit int
+}
+
+// Submissions lists the submissions LeetCode has recorded for a problem. Unlike the
+// workspace history, this includes submissions made from the website or other machines.
+func (a *App) Submissions(ctx context.Context, opts SubmissionsOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	problemKey := strings.TrimSpace(opts.ProblemKey)
+	if problemKey == "" {
+		return fmt.Errorf("problem key is required")
+	}
+	lister, ok := a.LeetCode.(lcx.SubmissionLister)
+	if !ok {
+		return fmt.Errorf("leetcode client does not support listing submissions")
+	}
+
+	cfg, err := a.loadConfigRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
+		return errNoSession
+	}
+	a.injectAuth(cfg)
+
+	subs, err := lister.ListSubmissions(ctx, problemKey, lcx.ListSubmissionsOptions{Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+
+	if a.Output != nil {
+		if err := a.Output.PrintSubmissions(ctx, subs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
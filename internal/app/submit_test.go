@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/therootusr/go-leetcode"
 	"vleet/internal/config"
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
 	"vleet/internal/output"
 	"vleet/internal/workspace"
 )
@@ -227,3 +231,108 @@ func TestApp_Submit_Sanity_SubmitAndPoll(t *testing.T) {
 		t.Fatalf("expected output to contain memory, got:\n%s", s)
 	}
 }
+
+// fakeProgressClient reports two intermediate checks before the verdict.
+type fakeProgressClient struct {
+	fakeSubmitClient
+}
+
+func (c *fakeProgressClient) PollSubmissionProgress(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions, onPoll lcx.PollFunc) (lcx.SubmissionResult, error) {
+	onPoll("PENDING", 1)
+	onPoll("STARTED", 2)
+	r, err := c.PollSubmission(ctx, id, opts)
+	return lcx.SubmissionResult{SubmissionResult: r}, err
+}
+
+func TestApp_Submit_Events_StreamsNDJSON(t *testing.T) {
+	t.Parallel()
+
+	lc := &fakeProgressClient{fakeSubmitClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+		id:                 7,
+		result:             leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms"},
+	}}
+	a, _, _ := newStatusTestApp(t, lc)
+	var out bytes.Buffer
+	pr := output.NewStdPrinter(&out, &bytes.Buffer{}, true)
+	pr.Events = true
+	a.Output = pr
+	a.Progress = pr.PrintEvent
+
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	want := []string{
+		`{"schema":"vleet/v1","kind":"event","data":{"event":"submitted","submission_id":7}}`,
+		`{"schema":"vleet/v1","kind":"event","data":{"event":"polling","submission_id":7,"state":"PENDING","attempt":1}}`,
+		`{"schema":"vleet/v1","kind":"event","data":{"event":"polling","submission_id":7,"state":"STARTED","attempt":2}}`,
+		`{"schema":"vleet/v1","kind":"event","data":{"event":"result","submission_id":7,"result":{"state":"SUCCESS","status":"Accepted","runtime":"4 ms"}}}`,
+	}
+	if got := strings.Split(strings.TrimSpace(out.String()), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("events:\n%s\nwant:\n%s", out.String(), strings.Join(want, "\n"))
+	}
+
+	// Failures end the stream with an error event.
+	out.Reset()
+	if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp", File: "a.cpp", Variant: "fast"}); err == nil {
+		t.Fatalf("expected an error for --file with --variant")
+	}
+	var env struct {
+		Data output.Event `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &env); err != nil || env.Data.Kind != output.EventError || env.Data.Error == "" {
+		t.Fatalf("error event = %q (err=%v)", out.String(), err)
+	}
+}
+
+func TestApp_Submit_FailOnReject_ReturnsVerdictError(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status   string
+		wantExit int
+	}{
+		{"Accepted", errx.ExitOK},
+		{"Wrong Answer", errx.ExitWrongAnswer},
+		{"Time Limit Exceeded", errx.ExitTimeLimit},
+		{"Memory Limit Exceeded", errx.ExitMemoryLimit},
+		{"Runtime Error", errx.ExitRuntimeError},
+		{"Compile Error", errx.ExitCompileError},
+		{"Output Limit Exceeded", errx.ExitNotAccepted},
+	}
+	for _, tc := range cases {
+		lc := &fakeProgressClient{fakeSubmitClient{
+			fakeLeetCodeClient: fakeLeetCodeClient{q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1"}},
+			id:                 7,
+			result:             leetcode.SubmissionResult{State: "SUCCESS", Status: tc.status},
+		}}
+		a, _, _ := newStatusTestApp(t, lc)
+		var out bytes.Buffer
+		pr := output.NewStdPrinter(&out, &bytes.Buffer{}, true)
+		pr.Events = true
+		a.Output = pr
+		a.Progress = pr.PrintEvent
+
+		if err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp"}); err != nil {
+			t.Fatalf("%s: Submit() without FailOnReject error = %v", tc.status, err)
+		}
+
+		out.Reset()
+		err := a.Submit(context.Background(), SubmitOptions{ProblemKey: "two-sum", Lang: "cpp", FailOnReject: true})
+		if got := errx.ExitCode(err); got != tc.wantExit {
+			t.Fatalf("%s: exit code = %d, want %d (err=%v)", tc.status, got, tc.wantExit, err)
+		}
+		if err == nil {
+			continue
+		}
+		var verr *VerdictError
+		if !errors.As(err, &verr) || verr.Result.Status != tc.status || !errors.Is(err, errx.ErrNotAccepted) {
+			t.Fatalf("%s: error = %#v, want a *VerdictError carrying the result", tc.status, err)
+		}
+		// The verdict went out as the result event; no error event follows it.
+		if strings.Contains(out.String(), `"event":"error"`) || !strings.Contains(out.String(), `"event":"result"`) {
+			t.Fatalf("%s: events:\n%s", tc.status, out.String())
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/index"
+	"vleet/internal/lcx"
+	"vleet/internal/workspace"
+)
+
+const (
+	kDefaultSyncWorkers = 4
+	kDefaultSyncRate    = 2 // requests per second
+
+	kSyncStateVersion = 1
+)
+
+type SyncOptions struct {
+	// Root is the solutions directory (default: workspace.root from config, else ".").
+	Root string
+
+	// Workers bounds how many problems are fetched concurrently (default: 4).
+	Workers int
+
+	// Rate caps LeetCode requests per second across all workers (default: 2).
+	Rate float64
+
+	// Force overwrites solution files that were not written by a previous sync, or that
+	// were edited since.
+	Force bool
+}
+
+// Sync writes the latest accepted solution for every (problem, language) of the account
+// into its workspace under root (see workspace.layout) and regenerates the root index.
+//
+// Progress is saved to <root>/.vleet/sync.json after every problem, so an interrupted
+// sync resumes where it stopped. Once a sync completes, later runs only page through
+// submissions newer than the newest one already seen.
+func (a *App) Sync(ctx context.Context, opts SyncOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if a.LeetCode == nil {
+		return fmt.Errorf("leetcode client is not configured")
+	}
+	lister, ok := a.LeetCode.(lcx.AccountSubmissionLister)
+	if !ok {
+		return fmt.Errorf("leetcode client does not support listing account submissions")
+	}
+	fetcher, ok := a.LeetCode.(lcx.SubmissionFetcher)
+	if !ok {
+		return fmt.Errorf("leetcode client does not support fetching submissions")
+	}
+	if a.Renderer == nil {
+		return fmt.Errorf("renderer is not configured")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.LeetCode.Session) == "" {
+		return errNoSession
+	}
+	a.injectAuth(cfg)
+
+	root := strings.TrimSpace(opts.Root)
+	if root == "" {
+		if root, err = cfg.Workspace.RootDir(); err != nil {
+			return err
+		}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = kDefaultSyncWorkers
+	}
+	rate := opts.Rate
+	if rate <= 0 {
+		rate = kDefaultSyncRate
+	}
+
+	state, err := loadSyncState(root)
+	if err != nil {
+		return err
+	}
+
+	limiter := newRateLimiter(rate)
+	defer limiter.Stop()
+
+	jobs, newest, err := collectSyncJobs(ctx, lister, limiter, state)
+	if err != nil {
+		return err
+	}
+	if opts.Force {
+		jobs = appendSkippedJobs(jobs, state)
+	}
+	a.humanf("Found %d new accepted solution(s)\n", len(jobs))
+
+	s := &syncer{
+		app:     a,
+		root:    root,
+		layout:  cfg.Workspace.Layout,
+		force:   opts.Force,
+		fetcher: fetcher,
+		limiter: limiter,
+		state:   state,
+	}
+	errs := s.run(ctx, jobs, workers)
+
+	// Only move the watermark once everything up to it is on disk; otherwise the next run
+	// would not list the submissions that failed or were never reached.
+	if len(errs) == 0 && ctx.Err() == nil && newest > state.Watermark {
+		state.Watermark = newest
+		if err := state.save(root); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if _, err := a.writeIndex(ctx, root, state.indexEntries()); err != nil {
+		errs = append(errs, err)
+	}
+
+	a.humanf("Synced %d, skipped %d, failed %d\n", s.synced, s.skipped, len(errs))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("sync: %d problem(s) failed (re-run to retry): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+type syncJob struct {
+	Slug         string    `json:"slug"`
+	Lang         string    `json:"lang"`
+	SubmissionID int64     `json:"submission_id"`
+	Time         time.Time `json:"time"`
+}
+
+func (j syncJob) key() string { return j.Slug + "/" + j.Lang }
+
+// appendSkippedJobs re-queues solutions skipped by earlier runs (hand-written files) that
+// are older than the watermark and would otherwise never be listed again.
+func appendSkippedJobs(jobs []syncJob, state *syncState) []syncJob {
+	queued := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		queued[j.key()] = true
+	}
+	keys := make([]string, 0, len(state.Skipped))
+	for k := range state.Skipped {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !queued[k] {
+			jobs = append(jobs, state.Skipped[k])
+		}
+	}
+	return jobs
+}
+
+// collectSyncJobs pages through the account's submissions (newest first) down to the
+// state watermark and returns the newest accepted submission per (problem, language) that
+// isn't synced yet, plus the newest submission ID seen.
+func collectSyncJobs(ctx context.Context, lister lcx.AccountSubmissionLister, limiter *rateLimiter, state *syncState) ([]syncJob, int64, error) {
+	var jobs []syncJob
+	var newest int64
+	seen := make(map[string]bool)
+
+	var cursor lcx.SubmissionCursor
+	for {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, 0, err
+		}
+		page, err := lister.ListAccountSubmissions(ctx, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		reachedWatermark := false
+		for _, sub := range page.Submissions {
+			if sub.ID <= state.Watermark {
+				reachedWatermark = true
+				break
+			}
+			newest = max(newest, sub.ID)
+			if sub.Status != kAcceptedStatus || sub.TitleSlug == "" || !workspace.IsSupportedLang(sub.Lang) {
+				continue
+			}
+			job := syncJob{Slug: sub.TitleSlug, Lang: sub.Lang, SubmissionID: sub.ID, Time: sub.Time}
+			if seen[job.key()] {
+				continue
+			}
+			seen[job.key()] = true
+			if state.syncedID(sub.TitleSlug, sub.Lang) >= sub.ID {
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+
+		if reachedWatermark || !page.HasNext {
+			return jobs, newest, nil
+		}
+		cursor = page.Next
+	}
+}
+
+type syncer struct {
+	app     *App
+	root    string
+	layout  string
+	force   bool
+	fetcher lcx.SubmissionFetcher
+	limiter *rateLimiter
+
+	mu      sync.Mutex
+	state   *syncState
+	synced  int
+	skipped int
+}
+
+func (s *syncer) run(ctx context.Context, jobs []syncJob, workers int) []error {
+	ch := make(chan syncJob)
+	var mu sync.Mutex
+	var errs []error
+
+	var wg sync.WaitGroup
+	for range min(workers, max(len(jobs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				if err := s.syncOne(ctx, job); err != nil {
+					if ctx.Err() != nil {
+						continue // drain; the cancellation is reported once by Sync
+					}
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%s (%s): %w", job.Slug, job.Lang, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			break feed
+		case ch <- job:
+		}
+	}
+	close(ch)
+	wg.Wait()
+	return errs
+}
+
+func (s *syncer) syncOne(ctx context.Context, job syncJob) error {
+	if err := s.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	detail, err := s.fetcher.FetchSubmission(ctx, job.SubmissionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	q, err := s.app.LeetCode.FetchQuestion(ctx, job.Slug)
+	if err != nil {
+		return err
+	}
+
+	header, err := s.app.Renderer.RenderHeader(ctx, job.Lang, q)
+	if err != nil {
+		return err
+	}
+	content := header + "\n" + detail.Code
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	ws, err := s.app.Workspace.CreateWorkspace(ctx, s.root, q, job.Lang, workspace.CreateOptions{Layout: s.layout})
+	switch {
+	case err == nil:
+		err = s.app.Workspace.WriteSolution(ctx, ws, content)
+	case errors.Is(err, os.ErrExist):
+		ws, err = s.app.Workspace.LoadWorkspace(ctx, s.root, q.TitleSlug, job.Lang, "")
+		if err != nil {
+			return err
+		}
+		existing, err := s.app.Workspace.ReadSolution(ctx, ws)
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		owned := s.state.syncedHash(job.Slug, job.Lang) == contentHash(existing)
+		s.mu.Unlock()
+		if !owned && !s.force {
+			// Hand-written, or edited since sync wrote it; keep it.
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.skipped++
+			s.state.Skipped[job.key()] = job
+			s.app.humanf("skip %s (%s): solution exists and differs from what sync wrote (use --force)\n", job.Slug, job.Lang)
+			return s.state.save(s.root)
+		}
+		err = s.app.Workspace.ReplaceSolution(ctx, ws, content)
+	}
+	if err != nil {
+		return err
+	}
+
+	rel, relErr := filepath.Rel(s.root, ws.SolutionPath)
+	if relErr != nil {
+		rel = ws.SolutionPath
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.record(q, job, filepath.ToSlash(rel), contentHash(content))
+	s.synced++
+	s.app.humanf("synced %s (%s)\n", job.Slug, job.Lang)
+	return s.state.save(s.root)
+}
+
+// syncState is persisted as <root>/.vleet/sync.json.
+type syncState struct {
+	Version int `json:"version"`
+
+	// Watermark is the newest submission ID seen by the last complete sync.
+	Watermark int64 `json:"watermark"`
+
+	Problems map[string]*syncProblem `json:"problems"`
+
+	// Skipped holds solutions not written because a hand-written file exists, keyed by
+	// "<slug>/<lang>". `vleet sync --force` retries them.
+	Skipped map[string]syncJob `json:"skipped,omitempty"`
+}
+
+type syncProblem struct {
+	FrontendID string               `json:"frontend_id,omitempty"`
+	Title      string               `json:"title,omitempty"`
+	Difficulty string               `json:"difficulty,omitempty"`
+	Tags       []string             `json:"tags,omitempty"`
+	Langs      map[string]*syncLang `json:"langs"`
+}
+
+type syncLang struct {
+	SubmissionID int64     `json:"submission_id"`
+	File         string    `json:"file"`
+	Time         time.Time `json:"time"`
+
+	// SHA256 is the hash of the content sync wrote. A file that no longer matches it was
+	// edited by hand and is not replaced without --force.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+func syncStatePath(root string) string {
+	return filepath.Join(root, ".vleet", "sync.json")
+}
+
+func loadSyncState(root string) (*syncState, error) {
+	p := syncStatePath(root)
+	st := &syncState{Version: kSyncStateVersion, Problems: map[string]*syncProblem{}, Skipped: map[string]syncJob{}}
+
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return st, nil
+		}
+		return nil, fmt.Errorf("read sync state %s: %w", p, err)
+	}
+	if err := json.Unmarshal(b, st); err != nil {
+		return nil, fmt.Errorf("parse sync state %s: %w", p, err)
+	}
+	if st.Problems == nil {
+		st.Problems = map[string]*syncProblem{}
+	}
+	if st.Skipped == nil {
+		st.Skipped = map[string]syncJob{}
+	}
+	return st, nil
+}
+
+// save writes the state atomically (temp file + rename) so an interrupted sync never
+// leaves a truncated state file behind.
+func (st *syncState) save(root string) error {
+	p := syncStatePath(root)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("create sync state dir %s: %w", filepath.Dir(p), err)
+	}
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync state: %w", err)
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write sync state %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		return fmt.Errorf("write sync state %s: %w", p, err)
+	}
+	return nil
+}
+
+func (st *syncState) syncedID(slug string, lang string) int64 {
+	if p := st.Problems[slug]; p != nil {
+		if l := p.Langs[lang]; l != nil {
+			return l.SubmissionID
+		}
+	}
+	return 0
+}
+
+// syncedHash returns the hash of the content sync last wrote for slug/lang, or "".
+func (st *syncState) syncedHash(slug string, lang string) string {
+	if p := st.Problems[slug]; p != nil {
+		if l := p.Langs[lang]; l != nil {
+			return l.SHA256
+		}
+	}
+	return ""
+}
+
+func (st *syncState) record(q leetcode.Question, job syncJob, file string, hash string) {
+	p := st.Problems[job.Slug]
+	if p == nil {
+		p = &syncProblem{Langs: map[string]*syncLang{}}
+		st.Problems[job.Slug] = p
+	}
+	p.FrontendID = q.FrontendID
+	p.Title = q.Title
+	p.Difficulty = q.Difficulty
+	p.Tags = p.Tags[:0]
+	for _, t := range q.TopicTags {
+		p.Tags = append(p.Tags, t.Name)
+	}
+	p.Langs[job.Lang] = &syncLang{SubmissionID: job.SubmissionID, File: file, Time: job.Time, SHA256: hash}
+	delete(st.Skipped, job.key())
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// indexEntries returns what sync knows about each problem, keyed by slug, for writeIndex.
+func (st *syncState) indexEntries() map[string]index.Entry {
+	entries := make(map[string]index.Entry, len(st.Problems))
+	for slug, p := range st.Problems {
+		e := index.Entry{
+			FrontendID:  p.FrontendID,
+			Title:       p.Title,
+			Slug:        slug,
+			Difficulty:  p.Difficulty,
+			Tags:        p.Tags,
+			LastVerdict: kAcceptedStatus,
+		}
+		for _, l := range p.Langs {
+			if l.Time.After(e.LastTime) {
+				e.LastTime = l.Time
+			}
+		}
+		entries[slug] = e
+	}
+	return entries
+}
+
+// rateLimiter hands out at most one token per interval across all callers.
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond))}
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) Stop() { l.ticker.Stop() }
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+// fakeSyncClient serves an account submission list (newest first) in pages of two.
+type fakeSyncClient struct {
+	fakeLeetCodeClient
+
+	mu        sync.Mutex
+	subs      []lcx.Submission
+	failFetch map[int64]bool
+	pages     int
+	fetched   []int64
+}
+
+func (c *fakeSyncClient) ListAccountSubmissions(ctx context.Context, cursor lcx.SubmissionCursor) (lcx.SubmissionPage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pages++
+
+	end := min(cursor.Offset+2, len(c.subs))
+	return lcx.SubmissionPage{
+		Submissions: c.subs[cursor.Offset:end],
+		HasNext:     end < len(c.subs),
+		Next:        lcx.SubmissionCursor{Offset: end},
+	}, nil
+}
+
+func (c *fakeSyncClient) FetchSubmission(ctx context.Context, id int64) (lcx.SubmissionDetail, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failFetch[id] {
+		return lcx.SubmissionDetail{}, errors.New("boom")
+	}
+	c.fetched = append(c.fetched, id)
+	return lcx.SubmissionDetail{ID: id, Code: fmt.Sprintf("// code %d", id)}, nil
+}
+
+func (c *fakeSyncClient) FetchQuestion(ctx context.Context, titleSlug string) (leetcode.Question, error) {
+	return leetcode.Question{TitleSlug: titleSlug, Title: titleSlug, FrontendID: "1", Difficulty: "Easy"}, nil
+}
+
+func newSyncTestApp(lc leetcode.Client) *App {
+	return New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{LeetCode: config.LeetCodeAuth{Session: "sess"}}},
+		LeetCode:    lc,
+		Workspace:   workspace.NewFSManager(),
+		Renderer:    render.NewHTMLRenderer(),
+		Output:      output.NewStdPrinter(&bytes.Buffer{}, &bytes.Buffer{}, false),
+	})
+}
+
+func TestApp_Sync_ResumesAndFetchesOnlyNewSubmissions(t *testing.T) {
+	root := t.TempDir()
+	lc := &fakeSyncClient{
+		subs: []lcx.Submission{
+			{ID: 6, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"},
+			{ID: 5, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}, // older; superseded by 6
+			{ID: 4, TitleSlug: "add-two-numbers", Lang: "python3", Status: "Wrong Answer"},
+			{ID: 3, TitleSlug: "add-two-numbers", Lang: "python3", Status: "Accepted"},
+			{ID: 2, TitleSlug: "two-sum", Lang: "python3", Status: "Accepted"},
+			{ID: 1, TitleSlug: "two-sum", Lang: "erlang", Status: "Accepted"}, // no solution extension; ignored
+		},
+		failFetch: map[int64]bool{3: true},
+	}
+	a := newSyncTestApp(lc)
+	opts := SyncOptions{Root: root, Workers: 2, Rate: 1000}
+
+	// First run: one problem fails, the rest is written and recorded.
+	if err := a.Sync(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "add-two-numbers") {
+		t.Fatalf("Sync() error = %v, want failure for add-two-numbers", err)
+	}
+	b, err := os.ReadFile(filepath.Join(root, "two-sum", "solution.cpp"))
+	if err != nil || !strings.Contains(string(b), "// code 6") || !strings.HasPrefix(string(b), "// two-sum (Easy)") {
+		t.Fatalf("two-sum/solution.cpp = (%q, %v)", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "two-sum", "solution.py")); err != nil {
+		t.Fatalf("expected two-sum/solution.py: %v", err)
+	}
+
+	// Resume: only the failed submission is fetched again.
+	lc.failFetch = nil
+	lc.fetched = nil
+	if err := a.Sync(context.Background(), opts); err != nil {
+		t.Fatalf("Sync() resume error = %v", err)
+	}
+	if len(lc.fetched) != 1 || lc.fetched[0] != 3 {
+		t.Fatalf("resume fetched %v, want [3]", lc.fetched)
+	}
+	if _, err := os.Stat(filepath.Join(root, "add-two-numbers", "solution.py")); err != nil {
+		t.Fatalf("expected add-two-numbers/solution.py: %v", err)
+	}
+
+	// Incremental: a new submission arrives; only the first page is listed.
+	lc.subs = append([]lcx.Submission{{ID: 7, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}}, lc.subs...)
+	lc.fetched = nil
+	lc.pages = 0
+	if err := a.Sync(context.Background(), opts); err != nil {
+		t.Fatalf("Sync() incremental error = %v", err)
+	}
+	if lc.pages != 1 || len(lc.fetched) != 1 || lc.fetched[0] != 7 {
+		t.Fatalf("incremental sync listed %d page(s) and fetched %v, want 1 page and [7]", lc.pages, lc.fetched)
+	}
+	if b, _ := os.ReadFile(filepath.Join(root, "two-sum", "solution.cpp")); !strings.Contains(string(b), "// code 7") {
+		t.Fatalf("expected sync to update its own solution file, got:\n%s", b)
+	}
+
+	idx, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	for _, want := range []string{"[two-sum](two-sum/)", "cpp, python3", "[add-two-numbers](add-two-numbers/)"} {
+		if !strings.Contains(string(idx), want) {
+			t.Fatalf("index missing %q:\n%s", want, idx)
+		}
+	}
+}
+
+func TestApp_Sync_DoesNotOverwriteHandWrittenSolution(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "two-sum"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	solPath := filepath.Join(root, "two-sum", "solution.cpp")
+	if err := os.WriteFile(solPath, []byte("MINE\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	lc := &fakeSyncClient{subs: []lcx.Submission{{ID: 1, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}}}
+	a := newSyncTestApp(lc)
+
+	if err := a.Sync(context.Background(), SyncOptions{Root: root, Rate: 1000}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if b, _ := os.ReadFile(solPath); string(b) != "MINE\n" {
+		t.Fatalf("hand-written solution overwritten:\n%s", b)
+	}
+
+	if err := a.Sync(context.Background(), SyncOptions{Root: root, Rate: 1000, Force: true}); err != nil {
+		t.Fatalf("Sync(--force) error = %v", err)
+	}
+	// The skip is remembered even though the watermark already covers submission 1.
+	if b, _ := os.ReadFile(solPath); !strings.Contains(string(b), "// code 1") {
+		t.Fatalf("expected --force to overwrite the skipped solution, got:\n%s", b)
+	}
+}
+
+func TestApp_Sync_KeepsSolutionEditedAfterSync(t *testing.T) {
+	root := t.TempDir()
+	lc := &fakeSyncClient{subs: []lcx.Submission{{ID: 1, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}}}
+	a := newSyncTestApp(lc)
+	opts := SyncOptions{Root: root, Rate: 1000}
+
+	if err := a.Sync(context.Background(), opts); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	solPath := filepath.Join(root, "two-sum", "solution.cpp")
+	b, err := os.ReadFile(solPath)
+	if err != nil || !strings.Contains(string(b), "// code 1") {
+		t.Fatalf("solution.cpp = (%q, %v)", b, err)
+	}
+	edited := string(b) + "// my notes\n"
+	if err := os.WriteFile(solPath, []byte(edited), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// A newer accepted submission must not replace the edited file.
+	lc.subs = append([]lcx.Submission{{ID: 2, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}}, lc.subs...)
+	if err := a.Sync(context.Background(), opts); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if b, _ := os.ReadFile(solPath); string(b) != edited {
+		t.Fatalf("edited solution overwritten:\n%s", b)
+	}
+
+	// Once the edit is undone, the file is sync's again and is updated.
+	if err := os.WriteFile(solPath, []byte(strings.TrimSuffix(edited, "// my notes\n")), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lc.subs = append([]lcx.Submission{{ID: 3, TitleSlug: "two-sum", Lang: "cpp", Status: "Accepted"}}, lc.subs...)
+	if err := a.Sync(context.Background(), opts); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if b, _ := os.ReadFile(solPath); !strings.Contains(string(b), "// code 3") {
+		t.Fatalf("expected sync to update its unedited file, got:\n%s", b)
+	}
+}
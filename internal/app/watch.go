@@ -0,0 +1,172 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+type WatchOptions struct {
+	ProblemKey string
+	Lang       string
+	File       string
+	Variant    string
+
+	// Keys is an optional line-oriented command input (typically stdin):
+	// "s" submits the current solution, "r" re-runs, "q" quits.
+	Keys io.Reader
+
+	// Interval and Debounce tune the file watcher (see workspace.WatchOptions).
+	Interval time.Duration
+	Debounce time.Duration
+}
+
+// Watch re-runs the solution against the examples every time solution.<ext> is saved.
+// A save that arrives while a run is in flight cancels the stale run. Submitting is
+// never automatic; it requires an explicit "s" on Keys. Canceling ctx (Ctrl-C) is the
+// normal way to stop watching and is not an error.
+func (a *App) Watch(ctx context.Context, opts WatchOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(opts.ProblemKey) == "" {
+		return fmt.Errorf("problem key (titleSlug) is required")
+	}
+	if a.Workspace == nil {
+		return fmt.Errorf("workspace manager is not configured")
+	}
+
+	cfg, err := a.loadConfigOrDefault(ctx)
+	if err != nil {
+		return err
+	}
+	root, err := cfg.Workspace.RootDir()
+	if err != nil {
+		return err
+	}
+	lang, err := a.sourceLang(cfg, root, opts.ProblemKey, opts.Lang, opts.File, opts.Variant)
+	if err != nil {
+		return err
+	}
+	file, err := solutionFileOption(lang, opts.File, opts.Variant)
+	if err != nil {
+		return err
+	}
+	ws, err := a.Workspace.LoadWorkspace(ctx, root, opts.ProblemKey, lang, file)
+	if err != nil {
+		return err
+	}
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+
+	changes := workspace.WatchFile(watchCtx, ws.SolutionPath, workspace.WatchOptions{
+		Interval: opts.Interval,
+		Debounce: opts.Debounce,
+	})
+	keys := readKeys(watchCtx, opts.Keys)
+
+	a.humanf("watching %s (r=run, s=submit, q=quit)\n", ws.SolutionPath)
+
+	runOpts := RunOptions{ProblemKey: opts.ProblemKey, Lang: lang, File: file}
+
+	// At most one run is in flight; cancelRun/runDone belong to it.
+	var cancelRun context.CancelFunc
+	var runDone chan struct{}
+	stopRun := func() {
+		if cancelRun != nil {
+			cancelRun()
+			<-runDone
+			cancelRun, runDone = nil, nil
+		}
+	}
+	defer stopRun()
+
+	startRun := func() {
+		stopRun()
+		runCtx, cancel := context.WithCancel(watchCtx)
+		done := make(chan struct{})
+		cancelRun, runDone = cancel, done
+		go func() {
+			defer close(done)
+			a.humanf("running examples...\n")
+			if err := a.Run(runCtx, runOpts); err != nil {
+				if errors.Is(err, context.Canceled) && runCtx.Err() != nil {
+					return // superseded by a newer save (or shutting down)
+				}
+				a.printError(runCtx, err)
+			}
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			startRun()
+		case key, ok := <-keys:
+			if !ok {
+				keys = nil // input closed; keep watching
+				continue
+			}
+			switch key {
+			case "q", "quit":
+				return nil
+			case "r", "run":
+				startRun()
+			case "s", "submit":
+				stopRun()
+				if err := a.Submit(ctx, SubmitOptions{ProblemKey: opts.ProblemKey, Lang: lang, File: file}); err != nil {
+					a.printError(ctx, err)
+				}
+			case "":
+			default:
+				a.humanf("unknown key %q (r=run, s=submit, q=quit)\n", key)
+			}
+		}
+	}
+}
+
+// readKeys streams trimmed input lines until r is exhausted or ctx ends.
+func readKeys(ctx context.Context, r io.Reader) <-chan string {
+	if r == nil {
+		return nil
+	}
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			select {
+			case ch <- strings.ToLower(strings.TrimSpace(sc.Text())):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// humanf prints progress for human (non-JSON) output only.
+func (a *App) humanf(format string, args ...any) {
+	if sp, ok := a.Output.(*output.StdPrinter); ok && !sp.JSON {
+		_, _ = fmt.Fprintf(sp.Out, format, args...)
+	}
+}
+
+func (a *App) printError(ctx context.Context, err error) {
+	if a.Output != nil {
+		_ = a.Output.PrintError(ctx, err)
+	}
+}
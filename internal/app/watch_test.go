@@ -0,0 +1,139 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/config"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
+	"vleet/internal/workspace"
+)
+
+// fakeRunClient blocks the first run until it is canceled, then answers later runs.
+type fakeRunClient struct {
+	fakeLeetCodeClient
+
+	mu            sync.Mutex
+	runs          int
+	firstStarted  chan struct{}
+	firstCanceled chan struct{}
+}
+
+func (c *fakeRunClient) RunCode(ctx context.Context, req lcx.RunRequest) (lcx.RunID, error) {
+	c.mu.Lock()
+	c.runs++
+	n := c.runs
+	c.mu.Unlock()
+
+	if n == 1 {
+		close(c.firstStarted)
+		<-ctx.Done()
+		close(c.firstCanceled)
+		return "", ctx.Err()
+	}
+	return "run-2", nil
+}
+
+func (c *fakeRunClient) PollRun(ctx context.Context, id lcx.RunID, opts leetcode.PollOptions) (lcx.RunResult, error) {
+	return lcx.RunResult{State: "SUCCESS", Status: "Accepted", Correct: true, TotalCorrect: 1, TotalTestcases: 1}, nil
+}
+
+func TestApp_Watch_NewRunCancelsStaleRun(t *testing.T) {
+	dir := t.TempDir()
+	lc := &fakeRunClient{
+		fakeLeetCodeClient: fakeLeetCodeClient{
+			q: leetcode.Question{TitleSlug: "two-sum", QuestionID: "1", ExampleTestcases: "[1]\n1"},
+		},
+		firstStarted:  make(chan struct{}),
+		firstCanceled: make(chan struct{}),
+	}
+
+	var out, errBuf bytes.Buffer
+	outW := &syncWriter{w: &out}
+	a := New(App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{DefaultLang: "cpp", LeetCode: config.LeetCodeAuth{Session: "sess"}}},
+		LeetCode:    lc,
+		Workspace: &fakeWorkspaceManager{
+			ws: workspace.Workspace{
+				Dir:          dir,
+				ProblemKey:   "two-sum",
+				Lang:         "cpp",
+				SolutionPath: filepath.Join(dir, "solution.cpp"),
+			},
+			readSolution: "CODE\n",
+		},
+		Output: output.NewStdPrinter(outW, &syncWriter{w: &errBuf}, false),
+	})
+
+	keysR, keysW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Watch(context.Background(), WatchOptions{ProblemKey: "two-sum", Keys: keysR})
+	}()
+
+	_, _ = io.WriteString(keysW, "r\n")
+	select {
+	case <-lc.firstStarted:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("first run did not start")
+	}
+	_, _ = io.WriteString(keysW, "r\n")
+
+	select {
+	case <-lc.firstCanceled:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stale run was not canceled by the newer run")
+	}
+
+	const want = "Run: Accepted (1/1 testcases passed)"
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(outW.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected run result in output, got:\n%s", outW.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	_, _ = io.WriteString(keysW, "q\n")
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Watch() did not return after q")
+	}
+
+	if got := strings.Count(out.String(), "Run: "); got != 1 {
+		t.Fatalf("expected exactly one run result, got %d:\n%s", got, out.String())
+	}
+	if errBuf.Len() != 0 {
+		t.Fatalf("expected no errors for the canceled run, got:\n%s", errBuf.String())
+	}
+}
+
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprint(s.w)
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
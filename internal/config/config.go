@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -21,6 +22,82 @@ type Config struct {
 	DefaultLang string `yaml:"default_lang"`
 
 	LeetCode LeetCodeAuth `yaml:"leetcode"`
+
+	Workspace WorkspaceConfig `yaml:"workspace,omitempty"`
+
+	Git GitConfig `yaml:"git,omitempty"`
+
+	Hooks HooksConfig `yaml:"hooks,omitempty"`
+
+	// Format maps a LeetCode language slug to a formatter command that reads code on
+	// stdin and writes it to stdout, e.g. cpp: "clang-format --assume-filename=x.cpp".
+	// Used by `vleet fmt` and `vleet submit --format`.
+	Format map[string]string `yaml:"format,omitempty"`
+
+	MCP MCPConfig `yaml:"mcp,omitempty"`
+
+	Serve ServeConfig `yaml:"serve,omitempty"`
+}
+
+// ServeConfig controls `vleet serve`.
+type ServeConfig struct {
+	// Token is the bearer token HTTP clients must send (Authorization: Bearer <token>).
+	// Required: the server refuses to start without it. Treat as sensitive.
+	Token string `yaml:"token,omitempty"`
+}
+
+// MCPConfig controls `vleet mcp`.
+type MCPConfig struct {
+	// AllowSubmit lets MCP clients call submit_solution (default: false). Submissions
+	// are recorded on the LeetCode account, so this must be an explicit opt-in.
+	AllowSubmit bool `yaml:"allow_submit,omitempty"`
+}
+
+// HooksConfig holds shell commands run at points of the solve/submit lifecycle, in the
+// workspace dir with VLEET_* environment variables describing the problem.
+type HooksConfig struct {
+	// PostCreate runs after a new solution file is written (solve, fetch).
+	PostCreate string `yaml:"post_create,omitempty"`
+
+	// PreSubmit runs before a submit; a non-zero exit cancels the submit.
+	PreSubmit string `yaml:"pre_submit,omitempty"`
+
+	// PostSubmit runs after the verdict arrives and gets the verdict JSON on stdin.
+	PostSubmit string `yaml:"post_submit,omitempty"`
+}
+
+// GitConfig controls the optional git integration.
+type GitConfig struct {
+	// AutoCommit commits the workspace after an Accepted submission when it is inside a
+	// git repository (default: false).
+	AutoCommit bool `yaml:"autocommit,omitempty"`
+}
+
+// WorkspaceConfig controls where workspaces live.
+type WorkspaceConfig struct {
+	// Root is the directory holding all workspaces (default: the current directory).
+	// A leading "~/" is expanded to the home directory.
+	Root string `yaml:"root,omitempty"`
+
+	// Layout is a text/template for the workspace dir relative to Root, e.g.
+	// "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}" (default: "{{.Slug}}").
+	Layout string `yaml:"layout,omitempty"`
+}
+
+// RootDir returns Root with "~/" expanded, or "." when Root is unset.
+func (w WorkspaceConfig) RootDir() (string, error) {
+	root := strings.TrimSpace(w.Root)
+	if root == "" {
+		return ".", nil
+	}
+	if root == "~" || strings.HasPrefix(root, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand workspace.root %q: %w", root, err)
+		}
+		root = filepath.Join(home, strings.TrimPrefix(root, "~"))
+	}
+	return root, nil
 }
 
 // LeetCodeAuth holds LeetCode auth secrets. Treat as sensitive.
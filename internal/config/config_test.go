@@ -84,3 +84,22 @@ func TestFileStore_Load_RejectsInsecurePermissions(t *testing.T) {
 		t.Fatalf("Load() error = %q, want message to include %q", err.Error(), "insecure permissions")
 	}
 }
+
+func TestWorkspaceConfig_RootDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("no home dir: %v", err)
+	}
+
+	cases := map[string]string{
+		"":              ".",
+		"/srv/leetcode": "/srv/leetcode",
+		"~/leetcode":    filepath.Join(home, "leetcode"),
+	}
+	for in, want := range cases {
+		got, err := WorkspaceConfig{Root: in}.RootDir()
+		if err != nil || got != want {
+			t.Errorf("RootDir(%q) = (%q, %v), want %q", in, got, err, want)
+		}
+	}
+}
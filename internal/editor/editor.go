@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -12,18 +14,33 @@ const (
 	kDefaultEditor = "vim"
 )
 
+// OpenOptions describes optional editor placement hints.
+//
+// Editors that don't understand a hint ignore it; the file is always opened.
+type OpenOptions struct {
+	// Line is the 1-based line to place the cursor on (0 means editor default).
+	Line int
+
+	// Splits are extra files shown alongside filePath (vim/nvim: vertical splits).
+	Splits []string
+}
+
 // Runner launches an external editor process and blocks until it exits.
 // See docs/architecture.md "Editor runner".
 type Runner interface {
-	OpenFile(ctx context.Context, editorCmd string, filePath string) error
+	OpenFile(ctx context.Context, editorCmd string, filePath string, opts OpenOptions) error
 }
 
 // ProcessRunner is a Runner implemented via os/exec.
-type ProcessRunner struct{}
+type ProcessRunner struct {
+	// HoldInterrupts, if set, is called before the editor starts and its result once it
+	// exits, so a caller that cancels on SIGINT can ignore Ctrl-C meant for the editor.
+	HoldInterrupts func() (release func())
+}
 
 func NewProcessRunner() *ProcessRunner { return &ProcessRunner{} }
 
-func (r *ProcessRunner) OpenFile(ctx context.Context, editorCmd string, filePath string) error {
+func (r *ProcessRunner) OpenFile(ctx context.Context, editorCmd string, filePath string, opts OpenOptions) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
@@ -33,29 +50,114 @@ func (r *ProcessRunner) OpenFile(ctx context.Context, editorCmd string, filePath
 		return fmt.Errorf("filePath is required")
 	}
 
-	editorCmd = strings.TrimSpace(editorCmd)
-	if editorCmd == "" {
-		if env, ok := os.LookupEnv("EDITOR"); ok && env != "" {
-			editorCmd = env
-		} else {
-			editorCmd = kDefaultEditor
-		}
-	}
+	editorCmd = resolveEditorCmd(editorCmd)
 
 	parts := strings.Fields(editorCmd)
 	if len(parts) == 0 {
 		return fmt.Errorf("editor command is empty")
 	}
 	name := parts[0]
-	args := append(parts[1:], filePath)
+	args := buildArgs(name, parts[1:], filePath, opts)
 
-	cmd := exec.CommandContext(ctx, name, args...)
+	// Deliberately not exec.CommandContext: Ctrl-C in a terminal editor is an editing key
+	// (it reaches the whole foreground process group), and killing the editor on cancel
+	// could lose unsaved work. HoldInterrupts keeps that Ctrl-C from cancelling ctx; any
+	// other cancellation is reported afterwards so callers don't continue (e.g. to submit).
+	cmd := exec.Command(name, args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
+	if r.HoldInterrupts != nil {
+		release := r.HoldInterrupts()
+		defer release()
+	}
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("run editor %q: %w", editorCmd, err)
 	}
-	return nil
+	return ctx.Err()
+}
+
+// resolveEditorCmd applies the editor fallback chain: explicit command, then $EDITOR, then vim.
+func resolveEditorCmd(editorCmd string) string {
+	editorCmd = strings.TrimSpace(editorCmd)
+	if editorCmd != "" {
+		return editorCmd
+	}
+	if env, ok := os.LookupEnv("EDITOR"); ok && strings.TrimSpace(env) != "" {
+		return strings.TrimSpace(env)
+	}
+	return kDefaultEditor
+}
+
+type editorFlavor int
+
+const (
+	flavorUnknown editorFlavor = iota
+	flavorVim                  // vim, nvim, gvim, mvim: +N and -O splits
+	flavorVi                   // vi, nano, emacs, micro, kak: +N only
+	flavorGoto                 // code, subl, hx: file:N
+)
+
+func baseName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(filepath.Base(name)), ".exe")
+}
+
+func flavorOf(name string) editorFlavor {
+	switch baseName(name) {
+	case "vim", "nvim", "gvim", "mvim", "vimx":
+		return flavorVim
+	case "vi", "nano", "emacs", "emacsclient", "micro", "kak":
+		return flavorVi
+	case "code", "codium", "subl", "hx", "helix":
+		return flavorGoto
+	default:
+		return flavorUnknown
+	}
+}
+
+// buildArgs returns the editor argv (excluding the program name) for filePath and opts.
+// Unknown editors receive only the file path, which is always safe.
+func buildArgs(name string, base []string, filePath string, opts OpenOptions) []string {
+	args := append([]string(nil), base...)
+
+	switch flavorOf(name) {
+	case flavorVim:
+		if opts.Line > 0 {
+			args = append(args, "+"+strconv.Itoa(opts.Line))
+		}
+		splits := nonEmpty(opts.Splits)
+		if len(splits) > 0 {
+			// -O opens every file in a vertical split; the first window keeps focus,
+			// so +N applies to the solution file.
+			args = append(args, "-O")
+		}
+		args = append(args, filePath)
+		return append(args, splits...)
+	case flavorVi:
+		if opts.Line > 0 {
+			args = append(args, "+"+strconv.Itoa(opts.Line))
+		}
+		return append(args, filePath)
+	case flavorGoto:
+		if opts.Line > 0 {
+			if b := baseName(name); b == "code" || b == "codium" {
+				args = append(args, "--goto")
+			}
+			return append(args, filePath+":"+strconv.Itoa(opts.Line))
+		}
+		return append(args, filePath)
+	default:
+		return append(args, filePath)
+	}
+}
+
+func nonEmpty(paths []string) []string {
+	var out []string
+	for _, p := range paths {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
 }
@@ -0,0 +1,72 @@
+package editor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildArgs_PerEditorFlavor(t *testing.T) {
+	t.Parallel()
+
+	opts := OpenOptions{Line: 42, Splits: []string{"README.md", " ", "testcases.txt"}}
+
+	tests := []struct {
+		name string
+		cmd  string
+		base []string
+		want []string
+	}{
+		{
+			name: "vim opens at line with vertical splits",
+			cmd:  "vim",
+			want: []string{"+42", "-O", "solution.cpp", "README.md", "testcases.txt"},
+		},
+		{
+			name: "nvim by absolute path keeps base args",
+			cmd:  "/usr/bin/nvim",
+			base: []string{"--clean"},
+			want: []string{"--clean", "+42", "-O", "solution.cpp", "README.md", "testcases.txt"},
+		},
+		{
+			name: "nano understands +N but not splits",
+			cmd:  "nano",
+			want: []string{"+42", "solution.cpp"},
+		},
+		{
+			name: "vscode uses --goto file:line",
+			cmd:  "code",
+			base: []string{"--wait"},
+			want: []string{"--wait", "--goto", "solution.cpp:42"},
+		},
+		{
+			name: "helix uses file:line",
+			cmd:  "hx",
+			want: []string{"solution.cpp:42"},
+		},
+		{
+			name: "unknown editor gets only the file",
+			cmd:  "ed",
+			want: []string{"solution.cpp"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := buildArgs(tc.cmd, tc.base, "solution.cpp", opts)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("buildArgs(%q) = %q, want %q", tc.cmd, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildArgs_NoHints_OnlyFile(t *testing.T) {
+	t.Parallel()
+
+	got := buildArgs("vim", nil, "solution.cpp", OpenOptions{})
+	if want := []string{"solution.cpp"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("buildArgs() = %q, want %q", got, want)
+	}
+}
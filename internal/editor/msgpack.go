@@ -0,0 +1,303 @@
+package editor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// A minimal MessagePack codec covering what Neovim's msgpack-RPC API exchanges.
+// See https://github.com/msgpack/msgpack/blob/master/spec.md.
+//
+// Decoded values use: nil, bool, int64, uint64, float64, string, []byte, []any,
+// map[any]any and msgpackExt (Neovim's Buffer/Window/Tabpage handles).
+
+type msgpackExt struct {
+	Type int8
+	Data []byte
+}
+
+func msgpackEncode(w io.Writer, v any) error {
+	b, err := msgpackAppend(nil, v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func msgpackAppend(b []byte, v any) ([]byte, error) {
+	switch x := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if x {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case int:
+		return appendInt(b, int64(x)), nil
+	case int64:
+		return appendInt(b, x), nil
+	case uint64:
+		if x <= math.MaxInt64 {
+			return appendInt(b, int64(x)), nil
+		}
+		b = append(b, 0xcf)
+		return binary.BigEndian.AppendUint64(b, x), nil
+	case string:
+		n := len(x)
+		switch {
+		case n < 32:
+			b = append(b, 0xa0|byte(n))
+		case n <= math.MaxUint8:
+			b = append(b, 0xd9, byte(n))
+		case n <= math.MaxUint16:
+			b = append(b, 0xda)
+			b = binary.BigEndian.AppendUint16(b, uint16(n))
+		default:
+			b = append(b, 0xdb)
+			b = binary.BigEndian.AppendUint32(b, uint32(n))
+		}
+		return append(b, x...), nil
+	case []any:
+		n := len(x)
+		switch {
+		case n < 16:
+			b = append(b, 0x90|byte(n))
+		case n <= math.MaxUint16:
+			b = append(b, 0xdc)
+			b = binary.BigEndian.AppendUint16(b, uint16(n))
+		default:
+			b = append(b, 0xdd)
+			b = binary.BigEndian.AppendUint32(b, uint32(n))
+		}
+		var err error
+		for _, e := range x {
+			if b, err = msgpackAppend(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case []string:
+		arr := make([]any, len(x))
+		for i := range x {
+			arr[i] = x[i]
+		}
+		return msgpackAppend(b, arr)
+	case map[string]any:
+		n := len(x)
+		switch {
+		case n < 16:
+			b = append(b, 0x80|byte(n))
+		case n <= math.MaxUint16:
+			b = append(b, 0xde)
+			b = binary.BigEndian.AppendUint16(b, uint16(n))
+		default:
+			b = append(b, 0xdf)
+			b = binary.BigEndian.AppendUint32(b, uint32(n))
+		}
+		var err error
+		for k, e := range x {
+			if b, err = msgpackAppend(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = msgpackAppend(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported type %T", v)
+	}
+}
+
+func appendInt(b []byte, x int64) []byte {
+	switch {
+	case x >= 0 && x <= 0x7f:
+		return append(b, byte(x))
+	case x < 0 && x >= -32:
+		return append(b, byte(x))
+	case x >= math.MinInt8 && x <= math.MaxInt8:
+		return append(b, 0xd0, byte(x))
+	case x >= math.MinInt16 && x <= math.MaxInt16:
+		b = append(b, 0xd1)
+		return binary.BigEndian.AppendUint16(b, uint16(x))
+	case x >= math.MinInt32 && x <= math.MaxInt32:
+		b = append(b, 0xd2)
+		return binary.BigEndian.AppendUint32(b, uint32(x))
+	default:
+		b = append(b, 0xd3)
+		return binary.BigEndian.AppendUint64(b, uint64(x))
+	}
+}
+
+type msgpackDecoder struct {
+	r *bufio.Reader
+}
+
+func newMsgpackDecoder(r io.Reader) *msgpackDecoder {
+	return &msgpackDecoder{r: bufio.NewReader(r)}
+}
+
+func (d *msgpackDecoder) Decode() (any, error) {
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return d.readString(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.readArray(int(c & 0x0f))
+	case c&0xf0 == 0x80:
+		return d.readMap(int(c & 0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := d.readUint(1 << (c - 0xcc))
+		return n, err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		n, err := d.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case 0xca:
+		n, err := d.readUint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := d.readUint(8)
+		return math.Float64frombits(n), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readUint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(int(n))
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readUint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return d.readBytes(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.readUint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.readArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.readUint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.readMap(int(n))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.readExt(1 << (c - 0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readUint(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.readExt(int(n))
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported type byte %#x", c)
+	}
+}
+
+func (d *msgpackDecoder) readUint(size int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(d.r, buf[:size]); err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, b := range buf[:size] {
+		n = n<<8 | uint64(b)
+	}
+	return n, nil
+}
+
+func (d *msgpackDecoder) readBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (d *msgpackDecoder) readString(n int) (string, error) {
+	b, err := d.readBytes(n)
+	return string(b), err
+}
+
+func (d *msgpackDecoder) readArray(n int) ([]any, error) {
+	out := make([]any, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (d *msgpackDecoder) readMap(n int) (map[any]any, error) {
+	out := make(map[any]any, n)
+	for i := 0; i < n; i++ {
+		k, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (d *msgpackDecoder) readExt(n int) (msgpackExt, error) {
+	t, err := d.r.ReadByte()
+	if err != nil {
+		return msgpackExt{}, err
+	}
+	data, err := d.readBytes(n)
+	if err != nil {
+		return msgpackExt{}, err
+	}
+	return msgpackExt{Type: int8(t), Data: data}, nil
+}
+
+// msgpackInt converts a decoded integer (signed or unsigned) to int64.
+func msgpackInt(v any) (int64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return x, true
+	case uint64:
+		if x > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(x), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,302 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// kEnvNvim is set by Neovim for processes started inside it (e.g. :terminal).
+	kEnvNvim = "NVIM"
+
+	kNvimDialTimeout = 2 * time.Second
+	kNvimDoneEvent   = "vleet_done"
+
+	kMsgpackRPCRequest      = 0
+	kMsgpackRPCResponse     = 1
+	kMsgpackRPCNotification = 2
+)
+
+// kNvimDoneAutocmds are the buffer events that end a remote editing session:
+// the solution was written, or its window/buffer went away.
+var kNvimDoneAutocmds = []string{"BufWritePost", "BufHidden", "BufUnload", "BufDelete"}
+
+// NvimServerAddr returns the Neovim RPC address to use: the explicit server address
+// if set, else $NVIM (set when vleet runs inside a Neovim :terminal), else "".
+func NvimServerAddr(server string) string {
+	if s := strings.TrimSpace(server); s != "" {
+		return s
+	}
+	return strings.TrimSpace(os.Getenv(kEnvNvim))
+}
+
+// NvimRemoteRunner is a Runner that opens the file in an already-running Neovim instance
+// via msgpack-RPC instead of spawning a nested editor. OpenFile blocks until the buffer
+// is written or closed.
+type NvimRemoteRunner struct {
+	// Addr is a Unix socket path (or host:port for --listen over TCP).
+	Addr string
+
+	// Fallback is used when Addr is empty or Neovim cannot be reached (optional).
+	Fallback Runner
+
+	// Explicit means Addr was given by the user (--server) rather than taken from $NVIM;
+	// an unreachable explicit server is an error instead of a silent fallback.
+	Explicit bool
+}
+
+func NewNvimRemoteRunner(addr string, fallback Runner) *NvimRemoteRunner {
+	return &NvimRemoteRunner{Addr: addr, Fallback: fallback}
+}
+
+func (r *NvimRemoteRunner) OpenFile(ctx context.Context, editorCmd string, filePath string, opts OpenOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return fmt.Errorf("filePath is required")
+	}
+
+	addr := strings.TrimSpace(r.Addr)
+	if addr == "" {
+		if r.Fallback != nil {
+			return r.Fallback.OpenFile(ctx, editorCmd, filePath, opts)
+		}
+		return fmt.Errorf("nvim server address is empty")
+	}
+
+	c, err := dialNvim(ctx, addr)
+	if err != nil {
+		if r.Fallback != nil && !r.Explicit {
+			return r.Fallback.OpenFile(ctx, editorCmd, filePath, opts)
+		}
+		return fmt.Errorf("connect to nvim at %s: %w", addr, err)
+	}
+	defer c.Close()
+
+	// Unblock pending reads when the caller cancels (e.g. Ctrl-C).
+	stop := context.AfterFunc(ctx, func() { _ = c.Close() })
+	defer stop()
+
+	if err := r.edit(ctx, c, filePath, opts); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("nvim remote %s: %w", addr, err)
+	}
+	return nil
+}
+
+func (r *NvimRemoteRunner) edit(ctx context.Context, c *nvimConn, filePath string, opts OpenOptions) error {
+	info, err := c.Call("nvim_get_api_info")
+	if err != nil {
+		return err
+	}
+	infoArr, ok := info.([]any)
+	if !ok || len(infoArr) == 0 {
+		return fmt.Errorf("unexpected nvim_get_api_info result: %v", info)
+	}
+	chanID, ok := msgpackInt(infoArr[0])
+	if !ok {
+		return fmt.Errorf("unexpected nvim channel id: %v", infoArr[0])
+	}
+
+	escaped, err := c.fnameescape(filePath)
+	if err != nil {
+		return err
+	}
+	cmd := "tabedit "
+	if opts.Line > 0 {
+		cmd += "+" + strconv.Itoa(opts.Line) + " "
+	}
+	if _, err := c.Call("nvim_command", cmd+escaped); err != nil {
+		return err
+	}
+
+	bufv, err := c.Call("nvim_call_function", "bufnr", []any{"%"})
+	if err != nil {
+		return err
+	}
+	buf, ok := msgpackInt(bufv)
+	if !ok {
+		return fmt.Errorf("unexpected bufnr result: %v", bufv)
+	}
+
+	splits := nonEmpty(opts.Splits)
+	for _, s := range splits {
+		e, err := c.fnameescape(s)
+		if err != nil {
+			return err
+		}
+		if _, err := c.Call("nvim_command", "rightbelow vsplit "+e); err != nil {
+			return err
+		}
+	}
+	if len(splits) > 0 {
+		if _, err := c.Call("nvim_command", "1wincmd w"); err != nil {
+			return err
+		}
+	}
+
+	group, err := c.Call("nvim_create_augroup", fmt.Sprintf("vleet_remote_%d", chanID), map[string]any{"clear": true})
+	if err != nil {
+		return err
+	}
+	for _, ev := range kNvimDoneAutocmds {
+		_, err := c.Call("nvim_create_autocmd", []any{ev}, map[string]any{
+			"group":   group,
+			"buffer":  buf,
+			"command": fmt.Sprintf("call rpcnotify(%d, '%s', '%s')", chanID, kNvimDoneEvent, ev),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.WaitNotification(kNvimDoneEvent); err != nil {
+		return err
+	}
+
+	// Best effort: the augroup is inert once this channel closes anyway.
+	if gid, ok := msgpackInt(group); ok {
+		_, _ = c.Call("nvim_del_augroup_by_id", gid)
+	}
+	return ctx.Err()
+}
+
+// nvimConn is a minimal synchronous msgpack-RPC client. Notifications that arrive while
+// waiting for a response are queued for WaitNotification.
+type nvimConn struct {
+	conn   net.Conn
+	dec    *msgpackDecoder
+	nextID int64
+
+	notified []string
+}
+
+func dialNvim(ctx context.Context, addr string) (*nvimConn, error) {
+	network := "unix"
+	if !strings.ContainsAny(addr, `/\`) && strings.Contains(addr, ":") {
+		network = "tcp"
+	}
+	d := net.Dialer{Timeout: kNvimDialTimeout}
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &nvimConn{conn: conn, dec: newMsgpackDecoder(conn)}, nil
+}
+
+func (c *nvimConn) Close() error { return c.conn.Close() }
+
+func (c *nvimConn) Call(method string, args ...any) (any, error) {
+	c.nextID++
+	id := c.nextID
+
+	if args == nil {
+		args = []any{}
+	}
+	if err := msgpackEncode(c.conn, []any{kMsgpackRPCRequest, id, method, args}); err != nil {
+		return nil, fmt.Errorf("%s: write request: %w", method, err)
+	}
+
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		kind, _ := msgpackInt(msg[0])
+		switch kind {
+		case kMsgpackRPCResponse:
+			if len(msg) != 4 {
+				return nil, fmt.Errorf("%s: malformed response", method)
+			}
+			if gotID, _ := msgpackInt(msg[1]); gotID != id {
+				continue
+			}
+			if msg[2] != nil {
+				return nil, fmt.Errorf("%s: %s", method, nvimErrorMessage(msg[2]))
+			}
+			return msg[3], nil
+		case kMsgpackRPCNotification:
+			c.queueNotification(msg)
+		default:
+			// Requests from nvim to us are not expected; ignore them.
+		}
+	}
+}
+
+// WaitNotification blocks until a notification with the given method name arrives.
+func (c *nvimConn) WaitNotification(method string) error {
+	for {
+		for i, n := range c.notified {
+			if n == method {
+				c.notified = append(c.notified[:i], c.notified[i+1:]...)
+				return nil
+			}
+		}
+
+		msg, err := c.readMessage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("nvim closed the connection before the buffer was written or closed")
+			}
+			return err
+		}
+		if kind, _ := msgpackInt(msg[0]); kind == kMsgpackRPCNotification {
+			c.queueNotification(msg)
+		}
+	}
+}
+
+func (c *nvimConn) fnameescape(path string) (string, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	v, err := c.Call("nvim_call_function", "fnameescape", []any{path})
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected fnameescape result: %v", v)
+	}
+	return s, nil
+}
+
+func (c *nvimConn) readMessage() ([]any, error) {
+	v, err := c.dec.Decode()
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := v.([]any)
+	if !ok || len(msg) < 3 {
+		return nil, fmt.Errorf("malformed msgpack-rpc message: %v", v)
+	}
+	return msg, nil
+}
+
+func (c *nvimConn) queueNotification(msg []any) {
+	name, _ := msg[1].(string)
+	c.notified = append(c.notified, name)
+}
+
+// nvimErrorMessage extracts the message from an nvim error tuple [type, message].
+func nvimErrorMessage(v any) string {
+	if arr, ok := v.([]any); ok && len(arr) == 2 {
+		if s, ok := arr[1].(string); ok {
+			return s
+		}
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,237 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeNvim is a scripted msgpack-RPC server standing in for a running Neovim.
+type fakeNvim struct {
+	t    *testing.T
+	ln   net.Listener
+	addr string
+
+	// notifyOn is the autocmd event that triggers the vleet_done notification ("" = never).
+	notifyOn string
+
+	mu       sync.Mutex
+	commands []string
+	autocmds []string
+}
+
+func newFakeNvim(t *testing.T, notifyOn string) *fakeNvim {
+	t.Helper()
+
+	// Keep socket paths short: sun_path is limited to ~104 bytes on some platforms.
+	dir, err := os.MkdirTemp("", "nv")
+	if err != nil {
+		t.Fatalf("mkdir temp: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	addr := filepath.Join(dir, "s")
+	ln, err := net.Listen("unix", addr)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	f := &fakeNvim{t: t, ln: ln, addr: addr, notifyOn: notifyOn}
+	go f.serve()
+	return f
+}
+
+func (f *fakeNvim) serve() {
+	conn, err := f.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	dec := newMsgpackDecoder(conn)
+	for {
+		v, err := dec.Decode()
+		if err != nil {
+			return
+		}
+		msg := v.([]any)
+		id := msg[1]
+		method := msg[2].(string)
+		args := msg[3].([]any)
+
+		var result any
+		var notify bool
+		switch method {
+		case "nvim_get_api_info":
+			result = []any{int64(7), map[string]any{}}
+		case "nvim_call_function":
+			fn := args[0].(string)
+			fnArgs := args[1].([]any)
+			switch fn {
+			case "fnameescape":
+				result = strings.ReplaceAll(fnArgs[0].(string), " ", `\ `)
+			case "bufnr":
+				result = int64(3)
+			}
+		case "nvim_command":
+			f.mu.Lock()
+			f.commands = append(f.commands, args[0].(string))
+			f.mu.Unlock()
+		case "nvim_create_augroup":
+			result = int64(11)
+		case "nvim_create_autocmd":
+			ev := args[0].([]any)[0].(string)
+			opts := args[1].(map[any]any)
+			f.mu.Lock()
+			f.autocmds = append(f.autocmds, ev+" "+opts["command"].(string))
+			f.mu.Unlock()
+			notify = ev == f.notifyOn
+		case "nvim_del_augroup_by_id":
+		default:
+			f.t.Errorf("unexpected method %q", method)
+		}
+
+		if err := msgpackEncode(conn, []any{int64(kMsgpackRPCResponse), id, nil, result}); err != nil {
+			return
+		}
+		if notify {
+			_ = msgpackEncode(conn, []any{int64(kMsgpackRPCNotification), kNvimDoneEvent, []any{f.notifyOn}})
+		}
+	}
+}
+
+func (f *fakeNvim) snapshot() (commands []string, autocmds []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.commands...), append([]string(nil), f.autocmds...)
+}
+
+func TestNvimRemoteRunner_OpensInExistingInstance_WaitsForWrite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets")
+	}
+	t.Parallel()
+
+	nv := newFakeNvim(t, "BufWritePost")
+	r := NewNvimRemoteRunner(nv.addr, nil)
+
+	err := r.OpenFile(context.Background(), "nvim", "/w/two sum/solution.cpp", OpenOptions{
+		Line:   12,
+		Splits: []string{"/w/two sum/README.md"},
+	})
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+
+	commands, autocmds := nv.snapshot()
+	wantCommands := []string{
+		`tabedit +12 /w/two\ sum/solution.cpp`,
+		`rightbelow vsplit /w/two\ sum/README.md`,
+		`1wincmd w`,
+	}
+	if strings.Join(commands, "\n") != strings.Join(wantCommands, "\n") {
+		t.Fatalf("commands = %q, want %q", commands, wantCommands)
+	}
+	if len(autocmds) != len(kNvimDoneAutocmds) {
+		t.Fatalf("autocmds = %q, want one per %v", autocmds, kNvimDoneAutocmds)
+	}
+	if want := "BufWritePost call rpcnotify(7, 'vleet_done', 'BufWritePost')"; autocmds[0] != want {
+		t.Fatalf("autocmd[0] = %q, want %q", autocmds[0], want)
+	}
+}
+
+func TestNvimRemoteRunner_ContextCanceled_WhileWaiting(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets")
+	}
+	t.Parallel()
+
+	nv := newFakeNvim(t, "")
+	r := NewNvimRemoteRunner(nv.addr, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := r.OpenFile(ctx, "nvim", "/w/solution.cpp", OpenOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("OpenFile() error = %v, want context deadline exceeded", err)
+	}
+}
+
+type recordingRunner struct {
+	called bool
+	opts   OpenOptions
+}
+
+func (r *recordingRunner) OpenFile(ctx context.Context, editorCmd string, filePath string, opts OpenOptions) error {
+	r.called = true
+	r.opts = opts
+	return nil
+}
+
+func TestNvimRemoteRunner_Unreachable_UsesFallback(t *testing.T) {
+	t.Parallel()
+
+	fb := &recordingRunner{}
+	r := NewNvimRemoteRunner(filepath.Join(t.TempDir(), "missing.sock"), fb)
+
+	if err := r.OpenFile(context.Background(), "nvim", "/w/solution.cpp", OpenOptions{Line: 3}); err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	if !fb.called || fb.opts.Line != 3 {
+		t.Fatalf("fallback called=%v opts=%+v, want called with line 3", fb.called, fb.opts)
+	}
+}
+
+func TestNvimRemoteRunner_ExplicitUnreachable_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	fb := &recordingRunner{}
+	r := NewNvimRemoteRunner(filepath.Join(t.TempDir(), "missing.sock"), fb)
+	r.Explicit = true
+
+	err := r.OpenFile(context.Background(), "nvim", "/w/solution.cpp", OpenOptions{})
+	if err == nil || !strings.Contains(err.Error(), "connect to nvim") {
+		t.Fatalf("OpenFile() error = %v, want a connect error", err)
+	}
+	if fb.called {
+		t.Fatalf("fallback called for an explicit --server")
+	}
+}
+
+func TestMsgpack_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := []any{int64(-1), int64(300), int64(-70000), "hi", strings.Repeat("x", 40), nil, true, []any{int64(1)}}
+	b, err := msgpackAppend(nil, in)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	out, err := newMsgpackDecoder(strings.NewReader(string(b))).Decode()
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	arr := out.([]any)
+	if len(arr) != len(in) {
+		t.Fatalf("len = %d, want %d", len(arr), len(in))
+	}
+	for i := range in {
+		if inner, ok := in[i].([]any); ok {
+			if got := arr[i].([]any); len(got) != 1 || got[0] != inner[0] {
+				t.Fatalf("[%d] = %v, want %v", i, arr[i], in[i])
+			}
+			continue
+		}
+		if arr[i] != in[i] {
+			t.Fatalf("[%d] = %#v, want %#v", i, arr[i], in[i])
+		}
+	}
+}
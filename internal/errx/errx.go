@@ -3,6 +3,7 @@ package errx
 import (
 	"errors"
 	"fmt"
+	"os"
 )
 
 // ErrNotImplemented is used by skeleton implementations to explicitly signal
@@ -16,3 +17,144 @@ func NotImplemented(feature string) error {
 	}
 	return fmt.Errorf("%s: %w", feature, ErrNotImplemented)
 }
+
+// Error kinds. Mark an error with one so callers can branch with errors.Is and the CLI
+// can pick the exit code and remediation hint (see ExitCode, Hint).
+var (
+	// ErrUsage: the command line is invalid (unknown flag, missing or extra argument).
+	ErrUsage = errors.New("usage")
+
+	// ErrAuth: the LeetCode session is missing, expired or rejected.
+	ErrAuth = errors.New("auth")
+
+	// ErrNetwork: LeetCode could not be reached.
+	ErrNetwork = errors.New("network")
+
+	// ErrBlocked: LeetCode is rate-limiting the client or asking for a captcha.
+	ErrBlocked = errors.New("blocked")
+
+	// ErrNotFound: the problem, submission or workspace does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrPremium: the problem is only available with LeetCode Premium.
+	ErrPremium = errors.New("premium only")
+
+	// ErrUnsupportedLang: the language is unknown to vleet or not offered for the problem.
+	ErrUnsupportedLang = errors.New("unsupported language")
+
+	// ErrWorkspaceConflict: the workspace is ambiguous or would be overwritten. Errors
+	// wrapping os.ErrExist (an existing solution file) count as conflicts too.
+	ErrWorkspaceConflict = errors.New("workspace conflict")
+
+	// ErrNotAccepted: the submission was judged but not accepted. The verdict kinds below
+	// narrow it down; errors carrying one of them should carry ErrNotAccepted too.
+	ErrNotAccepted = errors.New("not accepted")
+
+	ErrWrongAnswer  = errors.New("wrong answer")
+	ErrTimeLimit    = errors.New("time limit exceeded")
+	ErrMemoryLimit  = errors.New("memory limit exceeded")
+	ErrRuntimeError = errors.New("runtime error")
+	ErrCompileError = errors.New("compile error")
+)
+
+// Exit codes of the vleet CLI. Documented in docs/v1/usage.md; never renumber.
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitNotImplemented  = 3
+	ExitAuth            = 4
+	ExitNetwork         = 5
+	ExitBlocked         = 6
+	ExitNotFound        = 7
+	ExitPremium         = 8
+	ExitUnsupportedLang = 9
+	ExitConflict        = 10
+	ExitNotAccepted     = 11
+	ExitWrongAnswer     = 12
+	ExitTimeLimit       = 13
+	ExitMemoryLimit     = 14
+	ExitRuntimeError    = 15
+	ExitCompileError    = 16
+)
+
+type kindInfo struct {
+	kind error
+	code string
+	exit int
+	hint string
+}
+
+// kKinds is checked in order; the first kind an error matches wins.
+var kKinds = []kindInfo{
+	{ErrUsage, "usage", ExitUsage, "run vleet help for the commands and their flags"},
+	{ErrNotImplemented, "not_implemented", ExitNotImplemented, ""},
+	{ErrAuth, "auth", ExitAuth, "copy the LEETCODE_SESSION and csrftoken cookies from a logged-in browser into leetcode.session / leetcode.csrftoken (vleet config show prints the path); sessions expire after a few weeks"},
+	{ErrBlocked, "blocked", ExitBlocked, "LeetCode is rate-limiting or showing a captcha: wait a few minutes, open leetcode.com in your browser and refresh the cookies in the config"},
+	{ErrNetwork, "network", ExitNetwork, "check your connection (and VLEET_BASE_URL if set), then retry"},
+	{ErrPremium, "premium", ExitPremium, "this problem requires LeetCode Premium on the account in leetcode.session"},
+	{ErrUnsupportedLang, "unsupported_lang", ExitUnsupportedLang, "use a LeetCode language slug the problem offers, e.g. cpp, java, python3, golang, javascript"},
+	{ErrWorkspaceConflict, "workspace_conflict", ExitConflict, "pick the solution with --lang/--variant/--file, or pass --force where the command supports it"},
+	{ErrNotFound, "not_found", ExitNotFound, "check the problem key: it is the titleSlug from the problem URL, e.g. two-sum"},
+	{ErrWrongAnswer, "wrong_answer", ExitWrongAnswer, ""},
+	{ErrTimeLimit, "time_limit_exceeded", ExitTimeLimit, ""},
+	{ErrMemoryLimit, "memory_limit_exceeded", ExitMemoryLimit, ""},
+	{ErrRuntimeError, "runtime_error", ExitRuntimeError, ""},
+	{ErrCompileError, "compile_error", ExitCompileError, ""},
+	{ErrNotAccepted, "not_accepted", ExitNotAccepted, ""},
+}
+
+// marked is an error tagged with a kind. The message is the wrapped error's, unchanged.
+type marked struct {
+	err  error
+	kind error
+}
+
+func (e *marked) Error() string   { return e.err.Error() }
+func (e *marked) Unwrap() []error { return []error{e.err, e.kind} }
+
+// Mark tags err with kind (one of the Err* kinds above) without changing its message.
+// It returns nil for a nil err.
+func Mark(err error, kind error) error {
+	if err == nil {
+		return nil
+	}
+	return &marked{err: err, kind: kind}
+}
+
+func lookup(err error) (kindInfo, bool) {
+	if err == nil {
+		return kindInfo{}, false
+	}
+	for _, k := range kKinds {
+		if errors.Is(err, k.kind) || (k.kind == ErrWorkspaceConflict && errors.Is(err, os.ErrExist)) {
+			return k, true
+		}
+	}
+	return kindInfo{}, false
+}
+
+// ExitCode maps err to the CLI exit code: ExitOK for nil, the kind's code for a marked
+// error, else ExitError.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if k, ok := lookup(err); ok {
+		return k.exit
+	}
+	return ExitError
+}
+
+// Code names err's kind for machine-readable output (e.g. "auth", "not_found"), or ""
+// when err has none.
+func Code(err error) string {
+	k, _ := lookup(err)
+	return k.code
+}
+
+// Hint returns a remediation hint for err's kind, or "" when there is none.
+func Hint(err error) string {
+	k, _ := lookup(err)
+	return k.hint
+}
@@ -0,0 +1,76 @@
+package errx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestMark_KeepsMessageAndChain(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("leetcode submit: status 403: forbidden")
+	err := fmt.Errorf("submit: %w", Mark(base, ErrAuth))
+
+	if got, want := err.Error(), "submit: leetcode submit: status 403: forbidden"; got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrAuth) || !errors.Is(err, base) {
+		t.Fatalf("errors.Is lost the kind or the cause: %v", err)
+	}
+	if Mark(nil, ErrAuth) != nil {
+		t.Fatalf("Mark(nil) != nil")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		err      error
+		wantExit int
+		wantCode string
+	}{
+		{nil, ExitOK, ""},
+		{errors.New("boom"), ExitError, ""},
+		{Mark(errors.New("x"), ErrUsage), ExitUsage, "usage"},
+		{NotImplemented("run"), ExitNotImplemented, "not_implemented"},
+		{Mark(errors.New("x"), ErrAuth), ExitAuth, "auth"},
+		{Mark(errors.New("x"), ErrNetwork), ExitNetwork, "network"},
+		{Mark(errors.New("x"), ErrBlocked), ExitBlocked, "blocked"},
+		{Mark(errors.New("x"), ErrNotFound), ExitNotFound, "not_found"},
+		{Mark(errors.New("x"), ErrPremium), ExitPremium, "premium"},
+		{Mark(errors.New("x"), ErrUnsupportedLang), ExitUnsupportedLang, "unsupported_lang"},
+		{Mark(errors.New("x"), ErrWorkspaceConflict), ExitConflict, "workspace_conflict"},
+		{fmt.Errorf("solution already exists at x: %w", os.ErrExist), ExitConflict, "workspace_conflict"},
+		{Mark(errors.New("x"), ErrNotAccepted), ExitNotAccepted, "not_accepted"},
+		{Mark(Mark(errors.New("x"), ErrNotAccepted), ErrWrongAnswer), ExitWrongAnswer, "wrong_answer"},
+		{Mark(Mark(errors.New("x"), ErrNotAccepted), ErrCompileError), ExitCompileError, "compile_error"},
+	}
+	for _, tc := range cases {
+		if got := ExitCode(tc.err); got != tc.wantExit {
+			t.Errorf("ExitCode(%v) = %d, want %d", tc.err, got, tc.wantExit)
+		}
+		if got := Code(tc.err); got != tc.wantCode {
+			t.Errorf("Code(%v) = %q, want %q", tc.err, got, tc.wantCode)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	t.Parallel()
+
+	if Hint(errors.New("boom")) != "" {
+		t.Fatalf("unmarked error has a hint")
+	}
+	for _, k := range kKinds {
+		// Verdicts are the user's to fix; there is nothing to suggest.
+		if k.kind == ErrNotImplemented || k.exit >= ExitNotAccepted {
+			continue
+		}
+		if Hint(Mark(errors.New("x"), k.kind)) == "" {
+			t.Errorf("%s: missing hint", k.code)
+		}
+	}
+}
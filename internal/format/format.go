@@ -0,0 +1,56 @@
+// Package format runs the user's per-language code formatters (config "format").
+package format
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Formatter formats source code with a formatter command.
+type Formatter interface {
+	// Format pipes code through command (stdin -> stdout) and returns the result.
+	Format(ctx context.Context, command string, code string) (string, error)
+}
+
+// ShellFormatter runs formatter commands with `sh -c` (`cmd /C` on Windows), e.g.
+// "clang-format --assume-filename=solution.cpp", "gofmt", "black -q -".
+type ShellFormatter struct{}
+
+func NewShellFormatter() *ShellFormatter { return &ShellFormatter{} }
+
+func (f *ShellFormatter) Format(ctx context.Context, command string, code string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(command) == "" {
+		return "", fmt.Errorf("formatter command is empty")
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = strings.NewReader(code)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("formatter %q: %w:\n%s", command, err, msg)
+		}
+		return "", fmt.Errorf("formatter %q: %w", command, err)
+	}
+	if stdout.Len() == 0 && strings.TrimSpace(code) != "" {
+		// Formatters that edit files in place (e.g. "clang-format -i") print nothing;
+		// accepting that would submit an empty solution.
+		return "", fmt.Errorf("formatter %q produced no output (it must read stdin and write stdout)", command)
+	}
+	return stdout.String(), nil
+}
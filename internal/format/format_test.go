@@ -0,0 +1,30 @@
+package format
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestShellFormatter_Format(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh syntax")
+	}
+
+	f := NewShellFormatter()
+	got, err := f.Format(context.Background(), "tr a-z A-Z", "int x;\n")
+	if err != nil || got != "INT X;\n" {
+		t.Fatalf("Format() = (%q, %v), want INT X;", got, err)
+	}
+
+	_, err = f.Format(context.Background(), "echo 'line 3: expected ;' >&2; exit 1", "int x\n")
+	if err == nil || !strings.Contains(err.Error(), "line 3: expected ;") {
+		t.Fatalf("Format(failing) error = %v, want the formatter's stderr", err)
+	}
+
+	if _, err := f.Format(context.Background(), "cat >/dev/null", "int x;\n"); err == nil {
+		t.Fatalf("Format() accepted empty output")
+	}
+}
@@ -0,0 +1,91 @@
+// Package gitx commits workspaces to git via the git CLI (no libgit2 dependency; the
+// user's git config, hooks and signing settings apply as usual).
+package gitx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+var (
+	// ErrNotRepo is returned when the directory is not inside a git work tree.
+	ErrNotRepo = errors.New("not inside a git repository")
+
+	// ErrNothingToCommit is returned when the directory has no changes to commit.
+	ErrNothingToCommit = errors.New("nothing to commit")
+)
+
+// Committer commits the contents of a directory.
+type Committer interface {
+	// Commit stages everything under dir and commits only those paths with message.
+	// It returns ErrNotRepo or ErrNothingToCommit when there is nothing to do.
+	Commit(ctx context.Context, dir string, message string) error
+}
+
+// CLI is a Committer that runs the git binary.
+type CLI struct {
+	// Path is the git executable (default: "git" from PATH).
+	Path string
+}
+
+func NewCLI() *CLI { return &CLI{Path: "git"} }
+
+func (c *CLI) Commit(ctx context.Context, dir string, message string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message is required")
+	}
+
+	out, err := c.run(ctx, dir, "rev-parse", "--is-inside-work-tree")
+	if err != nil || strings.TrimSpace(out) != "true" {
+		if err == nil || errors.As(err, new(*exec.ExitError)) {
+			return fmt.Errorf("%s: %w", dir, ErrNotRepo)
+		}
+		return err
+	}
+
+	if _, err := c.run(ctx, dir, "add", "--all", "--", "."); err != nil {
+		return err
+	}
+
+	// --quiet exits 1 when there are staged changes under dir.
+	_, err = c.run(ctx, dir, "diff", "--cached", "--quiet", "--", ".")
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return fmt.Errorf("%s: %w", dir, ErrNothingToCommit)
+	case !errors.As(err, &exitErr) || exitErr.ExitCode() != 1:
+		return err
+	}
+
+	// The pathspec keeps changes staged elsewhere in the repo out of this commit.
+	if _, err := c.run(ctx, dir, "commit", "--quiet", "--message", message, "--", "."); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CLI) run(ctx context.Context, dir string, args ...string) (string, error) {
+	bin := c.Path
+	if strings.TrimSpace(bin) == "" {
+		bin = "git"
+	}
+
+	cmd := exec.CommandContext(ctx, bin, append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,81 @@
+package gitx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gitOrSkip(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func newRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	gitOrSkip(t, dir, "init", "--quiet")
+	gitOrSkip(t, dir, "config", "user.name", "vleet test")
+	gitOrSkip(t, dir, "config", "user.email", "vleet@example.com")
+	gitOrSkip(t, dir, "config", "commit.gpgsign", "false")
+	return dir
+}
+
+func TestCLI_Commit_CommitsOnlyTheWorkspace(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepo(t)
+	ws := filepath.Join(repo, "two-sum")
+	if err := os.MkdirAll(ws, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws, "solution.cpp"), []byte("code\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// Staged work elsewhere in the repo must not be swept into the commit.
+	if err := os.WriteFile(filepath.Join(repo, "notes.md"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	gitOrSkip(t, repo, "add", "notes.md")
+
+	c := NewCLI()
+	if err := c.Commit(context.Background(), ws, "two-sum (cpp): Accepted"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if msg := gitOrSkip(t, repo, "log", "-1", "--format=%s"); strings.TrimSpace(msg) != "two-sum (cpp): Accepted" {
+		t.Fatalf("last commit = %q", msg)
+	}
+	files := gitOrSkip(t, repo, "show", "--name-only", "--format=", "HEAD")
+	if strings.TrimSpace(files) != "two-sum/solution.cpp" {
+		t.Fatalf("committed files = %q, want only the workspace", files)
+	}
+
+	if err := c.Commit(context.Background(), ws, "again"); !errors.Is(err, ErrNothingToCommit) {
+		t.Fatalf("Commit(unchanged) error = %v, want ErrNothingToCommit", err)
+	}
+}
+
+func TestCLI_Commit_OutsideRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	if err := NewCLI().Commit(context.Background(), dir, "msg"); !errors.Is(err, ErrNotRepo) {
+		t.Fatalf("Commit() error = %v, want ErrNotRepo", err)
+	}
+}
@@ -0,0 +1,146 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// kStateDirName is the per-workspace directory for vleet-managed state.
+	kStateDirName = ".vleet"
+
+	kHistoryFileName = "history.jsonl"
+
+	// StatePending marks a submission that was accepted by LeetCode but whose verdict
+	// was never observed (e.g. polling was interrupted). Resume with `vleet status`.
+	StatePending = "PENDING"
+)
+
+// Record is one submission attempt for a workspace.
+type Record struct {
+	Time         time.Time `json:"time"`
+	SubmissionID int64     `json:"submission_id"`
+	ProblemKey   string    `json:"problem_key"`
+	Lang         string    `json:"lang"`
+
+	// File is the submitted solution file, relative to the workspace dir when possible.
+	File string `json:"file,omitempty"`
+
+	// Variant is the named solution variant (solution.<variant>.<ext>) that was submitted.
+	Variant string `json:"variant,omitempty"`
+
+	// State is LeetCode's check state (SUCCESS/FAILURE) or StatePending.
+	State   string `json:"state"`
+	Status  string `json:"status,omitempty"`
+	Runtime string `json:"runtime,omitempty"`
+	Memory  string `json:"memory,omitempty"`
+}
+
+// Store persists submission history per workspace directory.
+type Store interface {
+	Append(ctx context.Context, dir string, r Record) error
+	List(ctx context.Context, dir string) ([]Record, error)
+}
+
+// FileStore keeps history as JSON lines in <workspace>/.vleet/history.jsonl.
+// Append-only JSONL keeps concurrent writers safe for small records and diffs readable
+// when workspaces are committed to git.
+type FileStore struct{}
+
+func NewFileStore() *FileStore { return &FileStore{} }
+
+// Path returns the history file path for a workspace dir.
+func Path(dir string) string {
+	return filepath.Join(dir, kStateDirName, kHistoryFileName)
+}
+
+func (s *FileStore) Append(ctx context.Context, dir string, r Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(dir) == "" {
+		return fmt.Errorf("workspace dir is required")
+	}
+
+	p := Path(dir)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("create history dir %s: %w", filepath.Dir(p), err)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encode history record: %w", err)
+	}
+	b = append(b, '\n')
+
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history %s: %w", p, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("write history %s: %w", p, err)
+	}
+	return nil
+}
+
+// List returns the workspace history oldest-first. A missing history file is not an error.
+func (s *FileStore) List(ctx context.Context, dir string) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := Path(dir)
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open history %s: %w", p, err)
+	}
+	defer f.Close()
+
+	var out []Record
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, fmt.Errorf("parse history %s line %d: %w", p, n, err)
+		}
+		out = append(out, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read history %s: %w", p, err)
+	}
+	return out, nil
+}
+
+// Latest returns the most recent record per submission ID, preserving first-seen order.
+// A resumed submission appends a final record after its PENDING one; this collapses them.
+func Latest(records []Record) []Record {
+	idx := make(map[int64]int, len(records))
+	var out []Record
+	for _, r := range records {
+		if r.SubmissionID != 0 {
+			if i, ok := idx[r.SubmissionID]; ok {
+				out[i] = r
+				continue
+			}
+			idx[r.SubmissionID] = len(out)
+		}
+		out = append(out, r)
+	}
+	return out
+}
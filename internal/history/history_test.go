@@ -0,0 +1,55 @@
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore_AppendList_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s := NewFileStore()
+	ctx := context.Background()
+
+	// Missing history is empty, not an error.
+	got, err := s.List(ctx, dir)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("List() on empty workspace = (%v, %v), want (nil, nil)", got, err)
+	}
+
+	t0 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	recs := []Record{
+		{Time: t0, SubmissionID: 1, ProblemKey: "two-sum", Lang: "cpp", File: "solution.cpp", State: StatePending},
+		{Time: t0.Add(time.Minute), SubmissionID: 2, ProblemKey: "two-sum", Lang: "cpp", File: "solution.cpp", State: "SUCCESS", Status: "Wrong Answer"},
+		{Time: t0.Add(2 * time.Minute), SubmissionID: 1, ProblemKey: "two-sum", Lang: "cpp", File: "solution.cpp", State: "SUCCESS", Status: "Accepted", Runtime: "4 ms"},
+	}
+	for _, r := range recs {
+		if err := s.Append(ctx, dir, r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".vleet", "history.jsonl")); err != nil {
+		t.Fatalf("expected history file: %v", err)
+	}
+
+	got, err = s.List(ctx, dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 3 || got[2].Status != "Accepted" || !got[0].Time.Equal(t0) {
+		t.Fatalf("List() = %+v", got)
+	}
+
+	latest := Latest(got)
+	if len(latest) != 2 {
+		t.Fatalf("Latest() len = %d, want 2: %+v", len(latest), latest)
+	}
+	if latest[0].SubmissionID != 1 || latest[0].Status != "Accepted" {
+		t.Fatalf("Latest()[0] = %+v, want submission 1 resolved to Accepted", latest[0])
+	}
+}
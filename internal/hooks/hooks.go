@@ -0,0 +1,88 @@
+// Package hooks runs the user's lifecycle hook commands (config "hooks") through the
+// system shell.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Hook names, as used in the config file.
+const (
+	PostCreate = "post_create"
+	PreSubmit  = "pre_submit"
+	PostSubmit = "post_submit"
+)
+
+// Hook is one invocation of a hook command.
+type Hook struct {
+	Name    string
+	Command string
+
+	// Dir is the working directory (the workspace dir).
+	Dir string
+
+	// Env is added to the inherited environment, e.g. VLEET_SLUG.
+	Env map[string]string
+
+	// Stdin is passed to the command (e.g. the verdict JSON for post_submit).
+	Stdin []byte
+}
+
+// Runner runs hook commands. A non-zero exit is returned as an error.
+type Runner interface {
+	Run(ctx context.Context, h Hook) error
+}
+
+// ShellRunner runs hooks with `sh -c` (`cmd /C` on Windows). Hook output goes to
+// Stdout/Stderr; callers typically pass stderr for both so JSON output stays clean.
+type ShellRunner struct {
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+func NewShellRunner(stdout io.Writer, stderr io.Writer) *ShellRunner {
+	return &ShellRunner{Stdout: stdout, Stderr: stderr}
+}
+
+func (r *ShellRunner) Run(ctx context.Context, h Hook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(h.Command) == "" {
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", h.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", h.Command)
+	}
+	cmd.Dir = h.Dir
+	cmd.Stdin = bytes.NewReader(h.Stdin)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+
+	cmd.Env = os.Environ()
+	keys := make([]string, 0, len(h.Env))
+	for k := range h.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cmd.Env = append(cmd.Env, k+"="+h.Env[k])
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook %q: %w", h.Name, h.Command, err)
+	}
+	return nil
+}
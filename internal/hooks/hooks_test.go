@@ -0,0 +1,39 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestShellRunner_EnvStdinAndExitStatus(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh syntax")
+	}
+
+	var out bytes.Buffer
+	r := NewShellRunner(&out, &out)
+	dir := t.TempDir()
+
+	err := r.Run(context.Background(), Hook{
+		Name:    PostSubmit,
+		Command: `printf '%s %s ' "$VLEET_SLUG" "$(pwd)"; cat`,
+		Dir:     dir,
+		Env:     map[string]string{"VLEET_SLUG": "two-sum"},
+		Stdin:   []byte(`{"status_msg":"Accepted"}`),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "two-sum ") || !strings.HasSuffix(got, ` {"status_msg":"Accepted"}`) {
+		t.Fatalf("output = %q", got)
+	}
+
+	err = r.Run(context.Background(), Hook{Name: PreSubmit, Command: "exit 3", Dir: dir})
+	if err == nil || !strings.Contains(err.Error(), "pre_submit hook") || !strings.Contains(err.Error(), "exit status 3") {
+		t.Fatalf("Run(exit 3) error = %v, want the hook name and exit status", err)
+	}
+}
@@ -0,0 +1,269 @@
+// Package httpapi is a local HTTP/JSON API over app.App for editor plugins and
+// dashboards (`vleet serve`). Like the MCP adapter it holds no logic of its own: each
+// endpoint calls the same app methods as the CLI and returns the CLI's --json output.
+// See docs/v1.1/leetcode-microservice-mcp-analysis.md.
+//
+// Endpoints (all require "Authorization: Bearer <serve.token>"):
+//
+//	GET  /v1/questions/{slug}              question JSON
+//	GET  /v1/workspaces                    local workspaces and their languages (kind "workspaces")
+//	GET  /v1/workspaces/{slug}/history     submission history of a workspace (kind "history")
+//	POST /v1/workspaces/{slug}/submit      submit {"lang","file","variant"}; verdict JSON
+//	GET  /v1/submissions/{id}              verdict of a submission (?problem=&lang=)
+//
+// Every response body, errors included (kind "error"), is a vleet/v1 envelope.
+// submit and submissions wait for the verdict. Clients that send
+// "Accept: text/event-stream" get server-sent events instead: keep-alive comments while
+// LeetCode judges, then one "result" (or "error") event.
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/app"
+	"vleet/internal/lcx"
+	"vleet/internal/output"
+)
+
+const (
+	// kKeepAliveInterval is how often an event stream gets a comment while waiting, so
+	// proxies and clients don't time out a long poll.
+	kKeepAliveInterval = 15 * time.Second
+
+	// kMaxBodySize bounds request bodies (they only carry small option objects).
+	kMaxBodySize = 1 << 20
+
+	kContentTypeJSON = "application/json"
+	kContentTypeSSE  = "text/event-stream"
+)
+
+// Server serves the HTTP API for one App.
+type Server struct {
+	App   *app.App
+	Token string
+
+	// KeepAlive overrides kKeepAliveInterval (for tests).
+	KeepAlive time.Duration
+}
+
+func NewServer(a *app.App, token string) *Server {
+	return &Server{App: a, Token: token}
+}
+
+// Handler returns the API's http.Handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/questions/{slug}", s.getQuestion)
+	mux.HandleFunc("GET /v1/workspaces", s.listWorkspaces)
+	mux.HandleFunc("GET /v1/workspaces/{slug}/history", s.getHistory)
+	mux.HandleFunc("POST /v1/workspaces/{slug}/submit", s.submit)
+	mux.HandleFunc("GET /v1/submissions/{id}", s.getSubmission)
+	return s.authenticate(mux)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="vleet"`)
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getQuestion(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	if s.App.LeetCode == nil {
+		writeError(w, http.StatusInternalServerError, errors.New("leetcode client is not configured"))
+		return
+	}
+	q, err := s.App.LeetCode.FetchQuestion(r.Context(), slug)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output.Envelope{Schema: output.SchemaVersion, Kind: output.KindQuestion, Data: output.NewQuestion(q)})
+}
+
+func (s *Server) listWorkspaces(w http.ResponseWriter, r *http.Request) {
+	problems, err := s.App.ListProblems(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	data := make([]output.Workspace, 0, len(problems))
+	for _, p := range problems {
+		data = append(data, output.Workspace{Slug: p.Slug, Dir: p.Dir, Langs: p.Langs})
+	}
+	writeJSON(w, http.StatusOK, output.Envelope{Schema: output.SchemaVersion, Kind: output.KindWorkspaces, Data: data})
+}
+
+func (s *Server) getHistory(w http.ResponseWriter, r *http.Request) {
+	records, err := s.App.ProblemHistory(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	data := make([]output.HistoryRecord, 0, len(records))
+	for _, rec := range records {
+		data = append(data, output.NewHistoryRecord(rec))
+	}
+	writeJSON(w, http.StatusOK, output.Envelope{Schema: output.SchemaVersion, Kind: output.KindHistory, Data: data})
+}
+
+type submitRequest struct {
+	Lang    string `json:"lang"`
+	File    string `json:"file"`
+	Variant string `json:"variant"`
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(io.LimitReader(r.Body, kMaxBodySize))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+	}
+	opts := app.SubmitOptions{
+		ProblemKey: r.PathValue("slug"),
+		Lang:       req.Lang,
+		File:       req.File,
+		Variant:    req.Variant,
+	}
+	s.longPoll(w, r, func(ctx context.Context, a *app.App) error {
+		return a.Submit(ctx, opts)
+	})
+}
+
+func (s *Server) getSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid submission id: %q", r.PathValue("id")))
+		return
+	}
+	opts := app.StatusOptions{
+		SubmissionID: leetcode.SubmissionID(id),
+		ProblemKey:   r.URL.Query().Get("problem"),
+		Lang:         r.URL.Query().Get("lang"),
+	}
+	s.longPoll(w, r, func(ctx context.Context, a *app.App) error {
+		return a.Status(ctx, opts)
+	})
+}
+
+// longPoll runs an app call that waits on LeetCode and writes what it printed, either as
+// a plain JSON response or, when the client accepts it, as an event stream.
+func (s *Server) longPoll(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, a *app.App) error) {
+	if !strings.Contains(r.Header.Get("Accept"), kContentTypeSSE) {
+		out, err := s.captured(r.Context(), fn)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", kContentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(out)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusNotAcceptable, errors.New("streaming is not supported by this connection"))
+		return
+	}
+	w.Header().Set("Content-Type", kContentTypeSSE)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	type result struct {
+		out []byte
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		out, err := s.captured(r.Context(), fn)
+		done <- result{out, err}
+	}()
+
+	interval := s.KeepAlive
+	if interval <= 0 {
+		interval = kKeepAliveInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case res := <-done:
+			if res.err != nil {
+				writeEvent(w, "error", errorBody(res.err))
+			} else {
+				writeEvent(w, "result", bytes.TrimSpace(res.out))
+			}
+			flusher.Flush()
+			return
+		case <-ticker.C:
+			_, _ = io.WriteString(w, ": waiting for verdict\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// captured runs fn against a copy of the app whose printer writes JSON into a buffer, so
+// the response carries exactly the CLI's --json output. The copy gets its own LeetCode
+// client: the app injects the session into the client on every call, and requests run
+// concurrently (a submit may poll for minutes).
+func (s *Server) captured(ctx context.Context, fn func(ctx context.Context, a *app.App) error) ([]byte, error) {
+	var out, errOut bytes.Buffer
+	a := *s.App
+	if c, ok := a.LeetCode.(*lcx.HttpClient); ok {
+		a.LeetCode = c.Clone()
+	}
+	a.Output = output.NewStdPrinter(&out, &errOut, true)
+	if err := fn(ctx, &a); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func writeEvent(w io.Writer, event string, data []byte) {
+	_, _ = fmt.Fprintf(w, "event: %s\n", event)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		_, _ = fmt.Fprintf(w, "data: %s\n", line)
+	}
+	_, _ = io.WriteString(w, "\n")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", kContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func errorBody(err error) []byte {
+	b, _ := json.Marshal(output.Envelope{Schema: output.SchemaVersion, Kind: output.KindError, Data: output.NewErrorData(err)})
+	return b
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", kContentTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(append(errorBody(err), '\n'))
+}
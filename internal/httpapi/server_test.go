@@ -0,0 +1,292 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/app"
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+const kTestToken = "s3cret"
+
+type fakeConfigStore struct{ cfg config.Config }
+
+func (s *fakeConfigStore) Load(ctx context.Context) (config.Config, error) { return s.cfg, nil }
+func (s *fakeConfigStore) Save(ctx context.Context, cfg config.Config) error {
+	s.cfg = cfg
+	return nil
+}
+
+// fakeLeetCode judges every submission as Accepted; PollSubmission closes polling (when
+// set) and waits for release (when set).
+type fakeLeetCode struct {
+	release   chan struct{}
+	polling   chan struct{}
+	submitted []leetcode.SubmitRequest
+}
+
+func (c *fakeLeetCode) FetchQuestion(ctx context.Context, slug string) (leetcode.Question, error) {
+	return leetcode.Question{QuestionID: "1", Title: "Two Sum", TitleSlug: slug, Difficulty: "Easy"}, nil
+}
+
+func (c *fakeLeetCode) Submit(ctx context.Context, req leetcode.SubmitRequest) (leetcode.SubmissionID, error) {
+	c.submitted = append(c.submitted, req)
+	return 42, nil
+}
+
+func (c *fakeLeetCode) PollSubmission(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions) (leetcode.SubmissionResult, error) {
+	if c.polling != nil {
+		close(c.polling)
+	}
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return leetcode.SubmissionResult{}, ctx.Err()
+		}
+	}
+	return leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms"}, nil
+}
+
+func newTestServer(t *testing.T, lc *fakeLeetCode) (*httptest.Server, string) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "two-sum")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "solution.cpp"), []byte("class Solution {};\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	a := app.New(app.App{
+		ConfigStore: &fakeConfigStore{cfg: config.Config{
+			DefaultLang: "cpp",
+			LeetCode:    config.LeetCodeAuth{Session: "sess"},
+			Workspace:   config.WorkspaceConfig{Root: root},
+		}},
+		LeetCode:  lc,
+		Workspace: workspace.NewFSManager(),
+		Renderer:  render.NewHTMLRenderer(),
+		Output:    output.NewStdPrinter(io.Discard, io.Discard, false),
+		History:   history.NewFileStore(),
+	})
+	s := NewServer(a, kTestToken)
+	s.KeepAlive = 10 * time.Millisecond
+
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts, dir
+}
+
+func do(t *testing.T, ts *httptest.Server, method, path, body string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+kTestToken)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(b)
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	t.Parallel()
+	ts, _ := newTestServer(t, &fakeLeetCode{})
+
+	for _, auth := range []string{"", "Bearer wrong", kTestToken} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/workspaces", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Authorization %q: status = %d, want 401", auth, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_QuestionWorkspacesHistory(t *testing.T) {
+	t.Parallel()
+	ts, dir := newTestServer(t, &fakeLeetCode{})
+
+	resp := do(t, ts, http.MethodGet, "/v1/questions/two-sum", "", nil)
+	if body := readBody(t, resp); resp.StatusCode != http.StatusOK || !strings.Contains(body, `"kind":"question"`) || !strings.Contains(body, `"title":"Two Sum"`) {
+		t.Fatalf("question: %d %s", resp.StatusCode, body)
+	}
+
+	resp = do(t, ts, http.MethodGet, "/v1/workspaces", "", nil)
+	var problems struct {
+		Schema string             `json:"schema"`
+		Kind   string             `json:"kind"`
+		Data   []output.Workspace `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&problems); err != nil {
+		t.Fatalf("decode workspaces: %v", err)
+	}
+	if problems.Schema != output.SchemaVersion || problems.Kind != output.KindWorkspaces || len(problems.Data) != 1 ||
+		problems.Data[0].Slug != "two-sum" || strings.Join(problems.Data[0].Langs, ",") != "cpp" {
+		t.Fatalf("workspaces = %+v", problems)
+	}
+
+	if err := history.NewFileStore().Append(context.Background(), dir, history.Record{SubmissionID: 7, ProblemKey: "two-sum", Lang: "cpp", State: "SUCCESS", Status: "Accepted"}); err != nil {
+		t.Fatalf("append history: %v", err)
+	}
+	resp = do(t, ts, http.MethodGet, "/v1/workspaces/two-sum/history", "", nil)
+	var records struct {
+		Kind string                 `json:"kind"`
+		Data []output.HistoryRecord `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		t.Fatalf("decode history: %v", err)
+	}
+	if records.Kind != output.KindHistory || len(records.Data) != 1 || records.Data[0].SubmissionID != 7 {
+		t.Fatalf("history = %+v", records)
+	}
+
+	resp = do(t, ts, http.MethodGet, "/v1/workspaces/three-sum/history", "", nil)
+	var failed struct {
+		Schema string           `json:"schema"`
+		Kind   string           `json:"kind"`
+		Data   output.ErrorData `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&failed); err != nil || resp.StatusCode != http.StatusNotFound ||
+		failed.Schema != output.SchemaVersion || failed.Kind != output.KindError || failed.Data.Code != "not_found" || failed.Data.Message == "" {
+		t.Fatalf("missing workspace history: %d %+v (err=%v)", resp.StatusCode, failed, err)
+	}
+}
+
+func TestServer_Submit_JSON(t *testing.T) {
+	t.Parallel()
+	lc := &fakeLeetCode{}
+	ts, _ := newTestServer(t, lc)
+
+	resp := do(t, ts, http.MethodPost, "/v1/workspaces/two-sum/submit", `{"lang":"cpp"}`, nil)
+	body := readBody(t, resp)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("submit: %d %s", resp.StatusCode, body)
+	}
+	var result struct {
+		Kind string         `json:"kind"`
+		Data output.Verdict `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil || result.Kind != output.KindVerdict || result.Data.Status != "Accepted" {
+		t.Fatalf("submit result = %s (err=%v)", body, err)
+	}
+	if len(lc.submitted) != 1 || lc.submitted[0].TypedCode != "class Solution {};\n" {
+		t.Fatalf("submitted = %+v", lc.submitted)
+	}
+
+	resp = do(t, ts, http.MethodPost, "/v1/workspaces/two-sum/submit", `{"language":"cpp"}`, nil)
+	if body := readBody(t, resp); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unknown field: %d %s", resp.StatusCode, body)
+	}
+	resp = do(t, ts, http.MethodGet, "/v1/submissions/abc", "", nil)
+	if body := readBody(t, resp); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad id: %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestServer_Submission_StreamsEvents(t *testing.T) {
+	t.Parallel()
+	lc := &fakeLeetCode{release: make(chan struct{})}
+	ts, _ := newTestServer(t, lc)
+
+	resp := do(t, ts, http.MethodGet, "/v1/submissions/42", "", map[string]string{"Accept": "text/event-stream"})
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	var lines []string
+	released := false
+	for sc.Scan() {
+		line := sc.Text()
+		lines = append(lines, line)
+		// Judge the submission once the client has seen a keep-alive.
+		if strings.HasPrefix(line, ":") && !released {
+			close(lc.release)
+			released = true
+		}
+	}
+	stream := strings.Join(lines, "\n")
+	if !released {
+		t.Fatalf("expected a keep-alive comment before the result, got:\n%s", stream)
+	}
+	if !strings.Contains(stream, "event: result\ndata: {") || !strings.Contains(stream, `"Accepted"`) {
+		t.Fatalf("expected a result event, got:\n%s", stream)
+	}
+}
+
+func TestServer_QuestionWhileSubmitPolls(t *testing.T) {
+	t.Parallel()
+	lc := &fakeLeetCode{release: make(chan struct{}), polling: make(chan struct{})}
+	ts, _ := newTestServer(t, lc)
+
+	submitted := make(chan int, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/workspaces/two-sum/submit", strings.NewReader(`{"lang":"cpp"}`))
+		req.Header.Set("Authorization", "Bearer "+kTestToken)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			submitted <- 0
+			return
+		}
+		resp.Body.Close()
+		submitted <- resp.StatusCode
+	}()
+	<-lc.polling
+
+	// The submit is still waiting for its verdict; other requests must not queue behind it.
+	client := &http.Client{Timeout: 2 * time.Second}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/questions/two-sum", nil)
+	req.Header.Set("Authorization", "Bearer "+kTestToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("question while a submit polls: %v", err)
+	}
+	if body := readBody(t, resp); resp.StatusCode != http.StatusOK {
+		t.Fatalf("question: %d %s", resp.StatusCode, body)
+	}
+	resp.Body.Close()
+
+	close(lc.release)
+	if code := <-submitted; code != http.StatusOK {
+		t.Fatalf("submit status = %d", code)
+	}
+}
@@ -0,0 +1,140 @@
+// Package index renders the Markdown table of solved problems kept at the top of a
+// solutions root (README.md), e.g. for a GitHub portfolio.
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FileName is the index file written at the root.
+	FileName = "README.md"
+
+	kBeginMarker = "<!-- vleet:index:begin -->"
+	kEndMarker   = "<!-- vleet:index:end -->"
+
+	kProblemURLFormat = "https://leetcode.com/problems/%s/"
+)
+
+// Entry is one row of the index (one problem).
+type Entry struct {
+	FrontendID string
+	Title      string
+	Slug       string
+	Difficulty string
+	Tags       []string
+
+	// Dir is the workspace directory relative to the root, used for the link.
+	Dir string
+
+	// Langs are the languages with a solution file in the workspace.
+	Langs []string
+
+	LastVerdict string
+	LastTime    time.Time
+}
+
+// Render returns the index table, sorted by frontend ID (then slug).
+func Render(entries []Entry) string {
+	sorted := append([]Entry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, aErr := strconv.Atoi(sorted[i].FrontendID)
+		b, bErr := strconv.Atoi(sorted[j].FrontendID)
+		switch {
+		case aErr == nil && bErr == nil && a != b:
+			return a < b
+		case (aErr == nil) != (bErr == nil):
+			return aErr == nil
+		}
+		return sorted[i].Slug < sorted[j].Slug
+	})
+
+	var b strings.Builder
+	b.WriteString("| # | Title | Difficulty | Tags | Languages | Last verdict | Date |\n")
+	b.WriteString("|---|-------|------------|------|-----------|--------------|------|\n")
+	for _, e := range sorted {
+		title := e.Title
+		if title == "" {
+			title = e.Slug
+		}
+		link := escapeCell(title)
+		if e.Dir != "" {
+			link = fmt.Sprintf("[%s](%s/)", link, filepath.ToSlash(e.Dir))
+		}
+		if e.Slug != "" {
+			link += fmt.Sprintf(" ([LeetCode](%s))", fmt.Sprintf(kProblemURLFormat, e.Slug))
+		}
+		date := ""
+		if !e.LastTime.IsZero() {
+			date = e.LastTime.UTC().Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			escapeCell(e.FrontendID),
+			link,
+			escapeCell(e.Difficulty),
+			escapeCell(strings.Join(e.Tags, ", ")),
+			escapeCell(strings.Join(e.Langs, ", ")),
+			escapeCell(e.LastVerdict),
+			date,
+		)
+	}
+	return b.String()
+}
+
+// Write updates the index block in <root>/README.md. Only the text between the vleet
+// markers is replaced, so the rest of a hand-written README is preserved; a missing
+// README is created, and a README without markers gets the block appended.
+func Write(ctx context.Context, root string, entries []Entry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(root) == "" {
+		root = "."
+	}
+	p := filepath.Join(root, FileName)
+
+	block := kBeginMarker + "\n" + Render(entries) + kEndMarker + "\n"
+
+	var content string
+	b, err := os.ReadFile(p)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		content = "# Solutions\n\n" + block
+	case err != nil:
+		return fmt.Errorf("read index %s: %w", p, err)
+	default:
+		content = replaceBlock(string(b), block)
+	}
+
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write index %s: %w", p, err)
+	}
+	return nil
+}
+
+func replaceBlock(existing string, block string) string {
+	begin := strings.Index(existing, kBeginMarker)
+	end := strings.Index(existing, kEndMarker)
+	if begin < 0 || end < begin {
+		if existing != "" && !strings.HasSuffix(existing, "\n") {
+			existing += "\n"
+		}
+		return existing + "\n" + block
+	}
+	rest := existing[end+len(kEndMarker):]
+	rest = strings.TrimPrefix(rest, "\n")
+	return existing[:begin] + block + rest
+}
+
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
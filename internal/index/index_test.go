@@ -0,0 +1,65 @@
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender_SortsByFrontendID(t *testing.T) {
+	t.Parallel()
+
+	got := Render([]Entry{
+		{FrontendID: "10", Title: "Regular Expression Matching", Slug: "regular-expression-matching", Dir: "regular-expression-matching"},
+		{FrontendID: "2", Title: "Add Two Numbers", Slug: "add-two-numbers", Dir: "add-two-numbers", Langs: []string{"cpp", "python3"}},
+		{FrontendID: "1", Title: "Two | Sum", Slug: "two-sum", Difficulty: "Easy", Tags: []string{"Array", "Hash Table"},
+			Dir: "two-sum", LastVerdict: "Accepted", LastTime: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)},
+	})
+
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Render() lines = %d, want 5:\n%s", len(lines), got)
+	}
+	want := `| 1 | [Two \| Sum](two-sum/) ([LeetCode](https://leetcode.com/problems/two-sum/)) | Easy | Array, Hash Table |  | Accepted | 2026-03-04 |`
+	if lines[2] != want {
+		t.Fatalf("row 1 =\n%s\nwant\n%s", lines[2], want)
+	}
+	if !strings.HasPrefix(lines[3], "| 2 |") || !strings.HasPrefix(lines[4], "| 10 |") {
+		t.Fatalf("rows not sorted numerically:\n%s", got)
+	}
+}
+
+func TestWrite_PreservesSurroundingReadme(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	ctx := context.Background()
+
+	if err := Write(ctx, root, []Entry{{FrontendID: "1", Slug: "two-sum"}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	p := filepath.Join(root, FileName)
+	b, _ := os.ReadFile(p)
+	if !strings.HasPrefix(string(b), "# Solutions\n") || !strings.Contains(string(b), "two-sum") {
+		t.Fatalf("new README =\n%s", b)
+	}
+
+	custom := "# My solutions\n\nIntro.\n\n" + string(b[strings.Index(string(b), kBeginMarker):]) + "\nFooter.\n"
+	if err := os.WriteFile(p, []byte(custom), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := Write(ctx, root, []Entry{{FrontendID: "2", Slug: "add-two-numbers"}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	b, _ = os.ReadFile(p)
+	got := string(b)
+	if !strings.HasPrefix(got, "# My solutions\n\nIntro.\n\n") || !strings.HasSuffix(got, "\nFooter.\n") {
+		t.Fatalf("surrounding content not preserved:\n%s", got)
+	}
+	if strings.Contains(got, "two-sum") || !strings.Contains(got, "add-two-numbers") {
+		t.Fatalf("index block not replaced:\n%s", got)
+	}
+}
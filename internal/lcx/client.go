@@ -0,0 +1,341 @@
+// Package lcx adds the LeetCode endpoints vleet needs that github.com/therootusr/go-leetcode
+// does not cover (yet). It wraps leetcode.HttpClient and reuses its base URL, auth and
+// HTTP client, following the same request conventions (Referer/Origin, csrf header,
+// HTML-response detection).
+//
+// Anything here is a candidate for upstreaming into the library; see
+// docs/v1.1/leetcode-library-extraction-implementation.md.
+package lcx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+)
+
+const (
+	kDefaultBaseURL = "https://leetcode.com"
+
+	kHeaderAccept      = "Accept"
+	kHeaderContentType = "Content-Type"
+	kHeaderOrigin      = "Origin"
+	kHeaderReferer     = "Referer"
+	kHeaderUserAgent   = "User-Agent"
+	kHeaderXCSRFTOKEN  = "x-csrftoken"
+
+	kContentTypeApplicationJSON = "application/json"
+	kContentTypeTextHTML        = "text/html"
+
+	kCookieLeetCodeSession = "LEETCODE_SESSION"
+	kCookieCSRFTOKEN       = "csrftoken"
+
+	kMaxErrorBodyBytes    = 8 << 10
+	kMaxResponseBodyBytes = 2 << 20
+
+	kGraphQLPath                = "/graphql"
+	kProblemPathFormat          = "/problems/%s/"
+	kInterpretPathFormat        = "/problems/%s/interpret_solution/"
+	kSubmissionDetailPathFormat = "/submissions/detail/%s/"
+	kSubmissionCheckPathFormat  = "/submissions/detail/%s/check/"
+	kSubmissionsPath            = "/submissions/"
+
+	kAccountSubmissionsPathFormat = "/api/submissions/?offset=%d&limit=%d&lastkey=%s"
+
+	kDefaultPollInitialInterval = 1 * time.Second
+	kDefaultPollMaxInterval     = 5 * time.Second
+	kDefaultPollTimeout         = 2 * time.Minute
+)
+
+// Client is leetcode.Client plus the extra endpoints implemented in this package.
+type Client interface {
+	leetcode.Client
+	Runner
+	SubmissionLister
+	AccountSubmissionLister
+	SubmissionFetcher
+	ProgressPoller
+}
+
+// HttpClient extends leetcode.HttpClient. Auth, BaseURL, UserAgent and Http are shared
+// with the embedded client, so setting HttpClient.Auth applies to every endpoint.
+type HttpClient struct {
+	*leetcode.HttpClient
+}
+
+// Clone returns a client with its own copy of the settings (Auth in particular) that
+// shares the underlying http.Client, for callers that set Auth per request.
+func (c *HttpClient) Clone() *HttpClient {
+	hc := *c.HttpClient
+	return &HttpClient{HttpClient: &hc}
+}
+
+func NewHttpClient(hc *leetcode.HttpClient) *HttpClient {
+	if hc == nil {
+		hc = leetcode.NewHttpClient(leetcode.HttpClientOptions{})
+	}
+	return &HttpClient{HttpClient: hc}
+}
+
+func (c *HttpClient) baseURL() string {
+	base := strings.TrimRight(strings.TrimSpace(c.BaseURL), "/")
+	if base == "" {
+		return kDefaultBaseURL
+	}
+	return base
+}
+
+func (c *HttpClient) requireSession() error {
+	if strings.TrimSpace(c.Auth.Session) == "" {
+		return errx.Mark(fmt.Errorf("leetcode session cookie is required"), errx.ErrAuth)
+	}
+	return nil
+}
+
+// doJSON sends a request to path (relative to the base URL) and decodes the JSON response
+// into out. body, when non-nil, is sent as JSON. what names the endpoint in errors.
+func (c *HttpClient) doJSON(ctx context.Context, what string, method string, path string, referer string, body any, out any) error {
+	base := c.baseURL()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode %s payload: %w", what, err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, r)
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", what, err)
+	}
+	if body != nil {
+		req.Header.Set(kHeaderContentType, kContentTypeApplicationJSON)
+		req.Header.Set(kHeaderOrigin, base)
+	}
+	req.Header.Set(kHeaderAccept, kContentTypeApplicationJSON)
+	if referer != "" {
+		req.Header.Set(kHeaderReferer, base+referer)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set(kHeaderUserAgent, c.UserAgent)
+	}
+	if c.Auth.CsrfToken != "" {
+		req.Header.Set(kHeaderXCSRFTOKEN, c.Auth.CsrfToken)
+	}
+	// Attach cookies. These are secrets; never log them.
+	if c.Auth.Session != "" {
+		req.AddCookie(&http.Cookie{Name: kCookieLeetCodeSession, Value: c.Auth.Session})
+	}
+	if c.Auth.CsrfToken != "" {
+		req.AddCookie(&http.Cookie{Name: kCookieCSRFTOKEN, Value: c.Auth.CsrfToken})
+	}
+
+	httpClient := c.Http
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return classify(fmt.Errorf("leetcode %s request failed: %w", what, err))
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get(kHeaderContentType)
+	if strings.Contains(strings.ToLower(contentType), kContentTypeTextHTML) {
+		return errx.Mark(fmt.Errorf(
+			"leetcode %s: unexpected html response (status %d); leetcode may be blocking requests",
+			what,
+			resp.StatusCode,
+		), errx.ErrBlocked)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, kMaxErrorBodyBytes))
+		msg := strings.TrimSpace(string(snippet))
+		if msg == "" {
+			msg = resp.Status
+		}
+		err := fmt.Errorf("leetcode %s: status %d: %s", what, resp.StatusCode, msg)
+		if kind := statusKind(resp.StatusCode); kind != nil {
+			return errx.Mark(err, kind)
+		}
+		return err
+	}
+
+	dec := json.NewDecoder(io.LimitReader(resp.Body, kMaxResponseBodyBytes))
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("decode leetcode %s response: %w", what, err)
+	}
+	return nil
+}
+
+// graphQL posts query/variables to the GraphQL endpoint and decodes the "data" object into
+// out. GraphQL-level errors are returned as a single error. what names the query in errors.
+func (c *HttpClient) graphQL(ctx context.Context, what string, referer string, query string, variables any, out any) error {
+	reqBody := struct {
+		Query     string `json:"query"`
+		Variables any    `json:"variables"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.doJSON(ctx, what, http.MethodPost, kGraphQLPath, referer, reqBody, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			if strings.TrimSpace(e.Message) != "" {
+				msgs = append(msgs, e.Message)
+			}
+		}
+		if len(msgs) == 0 {
+			return fmt.Errorf("leetcode %s: unknown graphql error", what)
+		}
+		return fmt.Errorf("leetcode %s: %s", what, strings.Join(msgs, "; "))
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return fmt.Errorf("leetcode %s: missing data in response", what)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(resp.Data))
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("decode leetcode %s response: %w", what, err)
+	}
+	return nil
+}
+
+// pollCheck polls the submission check endpoint for id (a submission ID or interpret ID)
+// with exponential backoff until the state is terminal, then returns the raw payload.
+// onPoll, when non-nil, is called with each non-terminal state and the 1-based attempt.
+func (c *HttpClient) pollCheck(ctx context.Context, id string, opts leetcode.PollOptions, onPoll PollFunc) (map[string]any, error) {
+	initial := opts.InitialInterval
+	if initial <= 0 {
+		initial = kDefaultPollInitialInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = kDefaultPollMaxInterval
+	}
+	if maxInterval < initial {
+		maxInterval = initial
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = kDefaultPollTimeout
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path := fmt.Sprintf(kSubmissionCheckPathFormat, id)
+	referer := fmt.Sprintf(kSubmissionDetailPathFormat, id)
+
+	interval := initial
+	for attempt := 1; ; attempt++ {
+		if err := pollCtx.Err(); err != nil {
+			return nil, err
+		}
+
+		var m map[string]any
+		if err := c.doJSON(pollCtx, "submission check", http.MethodGet, path, referer, nil, &m); err != nil {
+			return nil, err
+		}
+
+		// Terminal states are typically SUCCESS (and sometimes FAILURE).
+		state := stringField(m, "state")
+		if state == "SUCCESS" || state == "FAILURE" {
+			return m, nil
+		}
+		if state == "" {
+			return nil, fmt.Errorf("leetcode submission check: missing state")
+		}
+		if onPoll != nil {
+			onPoll(state, attempt)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			return nil, pollCtx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func stringField(m map[string]any, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case json.Number:
+		return t.String()
+	default:
+		return strings.TrimSpace(fmt.Sprintf("%v", t))
+	}
+}
+
+func intField(m map[string]any, key string) int64 {
+	switch t := m[key].(type) {
+	case json.Number:
+		n, _ := t.Int64()
+		return n
+	case float64:
+		return int64(t)
+	case string:
+		n, _ := json.Number(strings.TrimSpace(t)).Int64()
+		return n
+	default:
+		return 0
+	}
+}
+
+func boolField(m map[string]any, key string) bool {
+	b, _ := m[key].(bool)
+	return b
+}
+
+func stringsField(m map[string]any, key string) []string {
+	arr, ok := m[key].([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(arr))
+	for _, v := range arr {
+		switch t := v.(type) {
+		case string:
+			out = append(out, t)
+		case nil:
+			out = append(out, "")
+		default:
+			out = append(out, fmt.Sprintf("%v", t))
+		}
+	}
+	return out
+}
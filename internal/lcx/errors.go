@@ -0,0 +1,81 @@
+package lcx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+)
+
+// FetchQuestion, Submit and PollSubmission wrap the library's methods so their errors
+// carry an errx kind like the endpoints in this package.
+
+func (c *HttpClient) FetchQuestion(ctx context.Context, titleSlug string) (leetcode.Question, error) {
+	q, err := c.HttpClient.FetchQuestion(ctx, titleSlug)
+	return q, classify(err)
+}
+
+func (c *HttpClient) Submit(ctx context.Context, req leetcode.SubmitRequest) (leetcode.SubmissionID, error) {
+	id, err := c.HttpClient.Submit(ctx, req)
+	return id, classify(err)
+}
+
+func (c *HttpClient) PollSubmission(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions) (leetcode.SubmissionResult, error) {
+	r, err := c.HttpClient.PollSubmission(ctx, id, opts)
+	return r, classify(err)
+}
+
+// classify marks a go-leetcode error with its errx kind. The library returns untyped
+// errors, so besides net errors this matches the messages it produces (http_client.go);
+// drop it once the library exports typed errors.
+func classify(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return errx.Mark(err, errx.ErrNetwork)
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "session cookie is required"):
+		return errx.Mark(err, errx.ErrAuth)
+	case strings.Contains(msg, "unexpected html response"):
+		return errx.Mark(err, errx.ErrBlocked)
+	case strings.Contains(msg, "problem not found"):
+		return errx.Mark(err, errx.ErrNotFound)
+	}
+	if m := kStatusRE.FindStringSubmatch(msg); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		if kind := statusKind(code); kind != nil {
+			return errx.Mark(err, kind)
+		}
+	}
+	return err
+}
+
+// kStatusRE matches the library's "leetcode <endpoint>: status <code>: <body>" errors.
+var kStatusRE = regexp.MustCompile(`^leetcode [a-z ]+: status (\d{3}):`)
+
+// statusKind is the errx kind of a non-2xx LeetCode response, or nil.
+func statusKind(code int) error {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// LeetCode answers 403 to expired sessions and bad csrf tokens.
+		return errx.ErrAuth
+	case http.StatusNotFound:
+		return errx.ErrNotFound
+	case http.StatusTooManyRequests:
+		return errx.ErrBlocked
+	}
+	return nil
+}
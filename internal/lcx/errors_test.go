@@ -0,0 +1,84 @@
+package lcx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+)
+
+func TestHttpClient_Errors_CarryKinds(t *testing.T) {
+	t.Parallel()
+
+	respond := func(status int, contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}
+	}
+	fetchQuestion := func(c *HttpClient) error {
+		_, err := c.FetchQuestion(context.Background(), "two-sum")
+		return err
+	}
+	fetchSubmission := func(c *HttpClient) error {
+		_, err := c.FetchSubmission(context.Background(), 42)
+		return err
+	}
+
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		session string
+		call    func(c *HttpClient) error
+		want    error
+	}{
+		{"library 403", respond(http.StatusForbidden, "application/json", `{}`), "sess", fetchQuestion, errx.ErrAuth},
+		{"library captcha page", respond(http.StatusOK, "text/html", "<html>captcha</html>"), "sess", fetchQuestion, errx.ErrBlocked},
+		{"library unknown problem", respond(http.StatusOK, "application/json", `{"data":{"question":null}}`), "sess", fetchQuestion, errx.ErrNotFound},
+		{"lcx 429", respond(http.StatusTooManyRequests, "application/json", `{}`), "sess", fetchSubmission, errx.ErrBlocked},
+		{"lcx unknown submission", respond(http.StatusOK, "application/json", `{"data":{"submissionDetails":null}}`), "sess", fetchSubmission, errx.ErrNotFound},
+		{"lcx no session", respond(http.StatusOK, "application/json", `{}`), "", fetchSubmission, errx.ErrAuth},
+	}
+	for _, tc := range cases {
+		ts := httptest.NewServer(tc.handler)
+		c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+			BaseURL: ts.URL,
+			Http:    ts.Client(),
+			Auth:    leetcode.Auth{Session: tc.session},
+		}))
+		err := tc.call(c)
+		ts.Close()
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: error = %v, want kind %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestHttpClient_Errors_Network(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	if _, err := c.FetchQuestion(context.Background(), "two-sum"); !errors.Is(err, errx.ErrNetwork) {
+		t.Fatalf("FetchQuestion() error = %v, want a network error", err)
+	}
+	if _, err := c.FetchSubmission(context.Background(), 42); !errors.Is(err, errx.ErrNetwork) {
+		t.Fatalf("FetchSubmission() error = %v, want a network error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.FetchQuestion(ctx, "two-sum"); errors.Is(err, errx.ErrNetwork) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("FetchQuestion(canceled) error = %v, want context.Canceled only", err)
+	}
+}
@@ -0,0 +1,64 @@
+package lcx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+// PollFunc observes a submission that is still being judged: state is LeetCode's
+// non-terminal check state (e.g. PENDING, STARTED) and attempt counts checks from 1.
+type PollFunc func(state string, attempt int)
+
+// SubmissionResult is a judged submission. Besides leetcode.SubmissionResult it carries
+// the testcase a rejected submission failed on (e.g. Wrong Answer); those fields are
+// empty when LeetCode doesn't report one.
+type SubmissionResult struct {
+	leetcode.SubmissionResult
+
+	LastTestcase   string // the failing input, one argument per line
+	ExpectedOutput string
+	CodeOutput     string
+	StdOutput      string
+}
+
+// ProgressPoller polls a submission like leetcode.Client.PollSubmission, reporting each
+// intermediate check to onPoll.
+type ProgressPoller interface {
+	PollSubmissionProgress(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions, onPoll PollFunc) (SubmissionResult, error)
+}
+
+func (c *HttpClient) PollSubmissionProgress(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions, onPoll PollFunc) (SubmissionResult, error) {
+	if err := ctx.Err(); err != nil {
+		return SubmissionResult{}, err
+	}
+	if id <= 0 {
+		return SubmissionResult{}, fmt.Errorf("submission id is required")
+	}
+	if err := c.requireSession(); err != nil {
+		return SubmissionResult{}, err
+	}
+
+	m, err := c.pollCheck(ctx, strconv.FormatInt(int64(id), 10), opts, onPoll)
+	if err != nil {
+		return SubmissionResult{}, err
+	}
+
+	return SubmissionResult{
+		// Same fields as leetcode.HttpClient.PollSubmission.
+		SubmissionResult: leetcode.SubmissionResult{
+			State:        stringField(m, "state"),
+			Status:       stringField(m, "status_msg"),
+			Runtime:      stringField(m, "runtime"),
+			Memory:       stringField(m, "memory"),
+			CompileError: stringField(m, "compile_error"),
+			RuntimeError: stringField(m, "runtime_error"),
+		},
+		LastTestcase:   stringField(m, "last_testcase"),
+		ExpectedOutput: stringField(m, "expected_output"),
+		CodeOutput:     stringField(m, "code_output"),
+		StdOutput:      stringField(m, "std_output"),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package lcx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+func TestHttpClient_PollSubmissionProgress_ReportsEachCheck(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/submissions/detail/42/check/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch polls.Add(1) {
+		case 1:
+			_, _ = w.Write([]byte(`{"state":"PENDING"}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"state":"STARTED"}`))
+		default:
+			_, _ = w.Write([]byte(`{"state":"SUCCESS","status_msg":"Accepted","runtime":"4 ms","memory":"10.2 MB"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	type check struct {
+		state   string
+		attempt int
+	}
+	var checks []check
+	res, err := c.PollSubmissionProgress(context.Background(), 42, leetcode.PollOptions{InitialInterval: time.Millisecond}, func(state string, attempt int) {
+		checks = append(checks, check{state, attempt})
+	})
+	if err != nil {
+		t.Fatalf("PollSubmissionProgress() error = %v", err)
+	}
+	if res.Status != "Accepted" || res.Runtime != "4 ms" || res.Memory != "10.2 MB" {
+		t.Fatalf("result = %+v", res)
+	}
+	if len(checks) != 2 || checks[0] != (check{"PENDING", 1}) || checks[1] != (check{"STARTED", 2}) {
+		t.Fatalf("checks = %+v, want PENDING/1 then STARTED/2", checks)
+	}
+}
+
+func TestHttpClient_PollSubmissionProgress_FailingTestcase(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"state":"SUCCESS","status_msg":"Wrong Answer","last_testcase":"[3,2,4]\n6","expected_output":"[1,2]","code_output":"[0,2]","std_output":"dbg\n"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{BaseURL: ts.URL, Http: ts.Client(), Auth: leetcode.Auth{Session: "sess"}}))
+	res, err := c.PollSubmissionProgress(context.Background(), 42, leetcode.PollOptions{}, nil)
+	if err != nil {
+		t.Fatalf("PollSubmissionProgress() error = %v", err)
+	}
+	if res.Status != "Wrong Answer" || res.LastTestcase != "[3,2,4]\n6" || res.ExpectedOutput != "[1,2]" || res.CodeOutput != "[0,2]" || res.StdOutput != "dbg" {
+		t.Fatalf("result = %+v", res)
+	}
+}
@@ -0,0 +1,146 @@
+package lcx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+// Runner runs code against custom testcases ("Run" in the LeetCode editor) without
+// creating a submission.
+type Runner interface {
+	RunCode(ctx context.Context, req RunRequest) (RunID, error)
+	PollRun(ctx context.Context, id RunID, opts leetcode.PollOptions) (RunResult, error)
+}
+
+type RunRequest struct {
+	TitleSlug  string
+	QuestionID string
+	Lang       string
+	TypedCode  string
+
+	// DataInput is the newline-separated testcase input (typically Question.ExampleTestcases).
+	DataInput string
+}
+
+// RunID is the interpret ID returned by LeetCode (e.g. "runcode_1700000000.123_abc").
+type RunID string
+
+// RunResult is the outcome of a run. Per-testcase slices are index-aligned.
+type RunResult struct {
+	State  string
+	Status string
+
+	// Correct is true when every testcase's output matched the expected output.
+	Correct bool
+
+	CodeAnswer     []string
+	ExpectedAnswer []string
+	StdOutput      []string
+
+	TotalCorrect   int
+	TotalTestcases int
+
+	Runtime string
+	Memory  string
+
+	CompileError string
+	RuntimeError string
+}
+
+func (c *HttpClient) RunCode(ctx context.Context, req RunRequest) (RunID, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	titleSlug := strings.TrimSpace(req.TitleSlug)
+	if titleSlug == "" {
+		return "", fmt.Errorf("titleSlug is required")
+	}
+	if strings.TrimSpace(req.QuestionID) == "" {
+		return "", fmt.Errorf("questionID is required")
+	}
+	if strings.TrimSpace(req.Lang) == "" {
+		return "", fmt.Errorf("lang is required")
+	}
+	if strings.TrimSpace(req.TypedCode) == "" {
+		return "", fmt.Errorf("typed_code is required")
+	}
+	if err := c.requireSession(); err != nil {
+		return "", err
+	}
+
+	payload := map[string]any{
+		"lang":        strings.TrimSpace(req.Lang),
+		"question_id": strings.TrimSpace(req.QuestionID),
+		"typed_code":  req.TypedCode,
+		"data_input":  req.DataInput,
+	}
+
+	var m map[string]any
+	err := c.doJSON(ctx, "run", http.MethodPost,
+		fmt.Sprintf(kInterpretPathFormat, titleSlug),
+		fmt.Sprintf(kProblemPathFormat, titleSlug),
+		payload, &m)
+	if err != nil {
+		return "", err
+	}
+
+	id := stringField(m, "interpret_id")
+	if id == "" {
+		return "", fmt.Errorf("leetcode run: missing interpret_id in response")
+	}
+	return RunID(id), nil
+}
+
+func (c *HttpClient) PollRun(ctx context.Context, id RunID, opts leetcode.PollOptions) (RunResult, error) {
+	if err := ctx.Err(); err != nil {
+		return RunResult{}, err
+	}
+	if strings.TrimSpace(string(id)) == "" {
+		return RunResult{}, fmt.Errorf("run id is required")
+	}
+	if err := c.requireSession(); err != nil {
+		return RunResult{}, err
+	}
+
+	m, err := c.pollCheck(ctx, string(id), opts, nil)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	return RunResult{
+		State:          stringField(m, "state"),
+		Status:         stringField(m, "status_msg"),
+		Correct:        boolField(m, "correct_answer"),
+		CodeAnswer:     stringsField(m, "code_answer"),
+		ExpectedAnswer: stringsField(m, "expected_code_answer"),
+		StdOutput:      stringsField(m, "std_output_list"),
+		TotalCorrect:   int(intField(m, "total_correct")),
+		TotalTestcases: int(intField(m, "total_testcases")),
+		Runtime:        stringField(m, "status_runtime"),
+		Memory:         stringField(m, "status_memory"),
+		CompileError:   stringField(m, "compile_error"),
+		RuntimeError:   stringField(m, "runtime_error"),
+	}, nil
+}
+
+// SplitTestcases splits newline-separated testcase input into per-case inputs, given the
+// number of cases LeetCode evaluated. It returns nil when the input doesn't divide evenly.
+func SplitTestcases(dataInput string, cases int) []string {
+	if cases <= 0 {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(dataInput, "\n"), "\n")
+	if len(lines)%cases != 0 {
+		return nil
+	}
+	per := len(lines) / cases
+	out := make([]string, 0, cases)
+	for i := 0; i < len(lines); i += per {
+		out = append(out, strings.Join(lines[i:i+per], "\n"))
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package lcx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+func TestHttpClient_RunCode_PollRun_Sanity(t *testing.T) {
+	t.Parallel()
+
+	var baseURL string
+	var polls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problems/two-sum/interpret_solution/":
+			if r.Method != http.MethodPost {
+				t.Errorf("method = %q, want POST", r.Method)
+			}
+			if got := r.Header.Get("Referer"); got != baseURL+"/problems/two-sum/" {
+				t.Errorf("Referer = %q", got)
+			}
+			if got := r.Header.Get("x-csrftoken"); got != "csrf" {
+				t.Errorf("x-csrftoken = %q, want %q", got, "csrf")
+			}
+			if c, err := r.Cookie("LEETCODE_SESSION"); err != nil || c.Value != "sess" {
+				t.Errorf("LEETCODE_SESSION cookie missing/invalid: %v", err)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			if body["data_input"] != "[2,7]\n9\n[3,3]\n6" || body["question_id"] != "1" {
+				t.Errorf("body = %v", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"interpret_id":"runcode_1.2_abc","test_case":"x"}`))
+		case "/submissions/detail/runcode_1.2_abc/check/":
+			w.Header().Set("Content-Type", "application/json")
+			if polls.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"state":"STARTED"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"state":"SUCCESS","status_msg":"Accepted","correct_answer":false,` +
+				`"code_answer":["[0,1]","[1,0]"],"expected_code_answer":["[0,1]","[0,1]"],` +
+				`"total_correct":1,"total_testcases":2,"status_runtime":"0 ms"}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	baseURL = ts.URL
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess", CsrfToken: "csrf"},
+	}))
+
+	id, err := c.RunCode(context.Background(), RunRequest{
+		TitleSlug:  "two-sum",
+		QuestionID: "1",
+		Lang:       "cpp",
+		TypedCode:  "CODE\n",
+		DataInput:  "[2,7]\n9\n[3,3]\n6",
+	})
+	if err != nil {
+		t.Fatalf("RunCode() error = %v", err)
+	}
+	if id != "runcode_1.2_abc" {
+		t.Fatalf("RunCode() id = %q", id)
+	}
+
+	got, err := c.PollRun(context.Background(), id, leetcode.PollOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatalf("PollRun() error = %v", err)
+	}
+	want := RunResult{
+		State:          "SUCCESS",
+		Status:         "Accepted",
+		CodeAnswer:     []string{"[0,1]", "[1,0]"},
+		ExpectedAnswer: []string{"[0,1]", "[0,1]"},
+		StdOutput:      nil,
+		TotalCorrect:   1,
+		TotalTestcases: 2,
+		Runtime:        "0 ms",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PollRun() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHttpClient_RunCode_RequiresSession(t *testing.T) {
+	t.Parallel()
+
+	c := NewHttpClient(nil)
+	_, err := c.RunCode(context.Background(), RunRequest{TitleSlug: "two-sum", QuestionID: "1", Lang: "cpp", TypedCode: "x"})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestSplitTestcases(t *testing.T) {
+	t.Parallel()
+
+	got := SplitTestcases("[2,7]\n9\n[3,3]\n6\n", 2)
+	if want := []string{"[2,7]\n9", "[3,3]\n6"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("SplitTestcases() = %q, want %q", got, want)
+	}
+	if got := SplitTestcases("a\nb\nc", 2); got != nil {
+		t.Fatalf("SplitTestcases() uneven = %q, want nil", got)
+	}
+}
@@ -0,0 +1,266 @@
+package lcx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"vleet/internal/errx"
+)
+
+const (
+	kDefaultSubmissionListLimit = 20
+
+	// LeetCode caps page size for questionSubmissionList; larger limits are paged.
+	kSubmissionListPageSize = 20
+
+	kQuestionSubmissionListQuery = `query submissionList($offset: Int!, $limit: Int!, $questionSlug: String!) {
+  questionSubmissionList(offset: $offset, limit: $limit, questionSlug: $questionSlug) {
+    hasNext
+    submissions {
+      id
+      statusDisplay
+      lang
+      runtime
+      memory
+      timestamp
+    }
+  }
+}`
+
+	kSubmissionDetailsQuery = `query submissionDetails($submissionId: Int!) {
+  submissionDetails(submissionId: $submissionId) {
+    code
+    lang {
+      name
+    }
+    question {
+      titleSlug
+    }
+  }
+}`
+)
+
+// SubmissionLister lists the signed-in user's submissions as recorded by LeetCode.
+type SubmissionLister interface {
+	ListSubmissions(ctx context.Context, titleSlug string, opts ListSubmissionsOptions) ([]Submission, error)
+}
+
+// SubmissionFetcher fetches the details (including the submitted code) of one of the
+// signed-in user's submissions.
+type SubmissionFetcher interface {
+	FetchSubmission(ctx context.Context, id int64) (SubmissionDetail, error)
+}
+
+// AccountSubmissionLister pages through all of the signed-in user's submissions across
+// problems, newest first.
+type AccountSubmissionLister interface {
+	ListAccountSubmissions(ctx context.Context, cursor SubmissionCursor) (SubmissionPage, error)
+}
+
+// SubmissionCursor addresses a page of the account submission list. The zero value is
+// the first (newest) page.
+type SubmissionCursor struct {
+	Offset  int
+	LastKey string
+}
+
+type SubmissionPage struct {
+	Submissions []Submission
+	HasNext     bool
+	Next        SubmissionCursor
+}
+
+type ListSubmissionsOptions struct {
+	// Limit is the maximum number of submissions returned, newest first (default: 20).
+	Limit int
+}
+
+// Submission is one entry of a problem's remote submission list.
+type Submission struct {
+	ID int64 `json:"id"`
+
+	// TitleSlug is only set by ListAccountSubmissions.
+	TitleSlug string `json:"title_slug,omitempty"`
+
+	Status  string    `json:"status"`
+	Lang    string    `json:"lang"`
+	Runtime string    `json:"runtime,omitempty"`
+	Memory  string    `json:"memory,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+func (c *HttpClient) ListSubmissions(ctx context.Context, titleSlug string, opts ListSubmissionsOptions) ([]Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	titleSlug = strings.TrimSpace(titleSlug)
+	if titleSlug == "" {
+		return nil, fmt.Errorf("titleSlug is required")
+	}
+	if err := c.requireSession(); err != nil {
+		return nil, err
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = kDefaultSubmissionListLimit
+	}
+
+	type gqlSubmission struct {
+		ID            json.Number `json:"id"`
+		StatusDisplay string      `json:"statusDisplay"`
+		Lang          string      `json:"lang"`
+		Runtime       string      `json:"runtime"`
+		Memory        string      `json:"memory"`
+		Timestamp     json.Number `json:"timestamp"`
+	}
+	var out []Submission
+	for offset := 0; len(out) < limit; {
+		page := min(kSubmissionListPageSize, limit-len(out))
+
+		var data struct {
+			List *struct {
+				HasNext     bool            `json:"hasNext"`
+				Submissions []gqlSubmission `json:"submissions"`
+			} `json:"questionSubmissionList"`
+		}
+		vars := map[string]any{"offset": offset, "limit": page, "questionSlug": titleSlug}
+		if err := c.graphQL(ctx, "submission list", fmt.Sprintf(kProblemPathFormat, titleSlug), kQuestionSubmissionListQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.List == nil {
+			return nil, fmt.Errorf("leetcode submission list: missing questionSubmissionList in response")
+		}
+
+		for _, s := range data.List.Submissions {
+			id, err := strconv.ParseInt(s.ID.String(), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("leetcode submission list: invalid submission id %q", s.ID)
+			}
+			sub := Submission{
+				ID:      id,
+				Status:  strings.TrimSpace(s.StatusDisplay),
+				Lang:    strings.TrimSpace(s.Lang),
+				Runtime: strings.TrimSpace(s.Runtime),
+				Memory:  strings.TrimSpace(s.Memory),
+			}
+			if ts, err := strconv.ParseInt(s.Timestamp.String(), 10, 64); err == nil && ts > 0 {
+				sub.Time = time.Unix(ts, 0).UTC()
+			}
+			out = append(out, sub)
+		}
+
+		if !data.List.HasNext || len(data.List.Submissions) == 0 {
+			break
+		}
+		offset += len(data.List.Submissions)
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// SubmissionDetail is a single submission including its source code.
+type SubmissionDetail struct {
+	ID        int64  `json:"id"`
+	TitleSlug string `json:"title_slug"`
+	Lang      string `json:"lang"`
+	Code      string `json:"code"`
+}
+
+func (c *HttpClient) FetchSubmission(ctx context.Context, id int64) (SubmissionDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return SubmissionDetail{}, err
+	}
+	if id <= 0 {
+		return SubmissionDetail{}, fmt.Errorf("submission id is required")
+	}
+	if err := c.requireSession(); err != nil {
+		return SubmissionDetail{}, err
+	}
+
+	var data struct {
+		Details *struct {
+			Code string `json:"code"`
+			Lang struct {
+				Name string `json:"name"`
+			} `json:"lang"`
+			Question struct {
+				TitleSlug string `json:"titleSlug"`
+			} `json:"question"`
+		} `json:"submissionDetails"`
+	}
+	idStr := strconv.FormatInt(id, 10)
+	vars := map[string]any{"submissionId": id}
+	if err := c.graphQL(ctx, "submission details", fmt.Sprintf(kSubmissionDetailPathFormat, idStr), kSubmissionDetailsQuery, vars, &data); err != nil {
+		return SubmissionDetail{}, err
+	}
+	if data.Details == nil {
+		return SubmissionDetail{}, errx.Mark(fmt.Errorf("submission not found: %d", id), errx.ErrNotFound)
+	}
+
+	return SubmissionDetail{
+		ID:        id,
+		TitleSlug: strings.TrimSpace(data.Details.Question.TitleSlug),
+		Lang:      strings.TrimSpace(data.Details.Lang.Name),
+		Code:      data.Details.Code,
+	}, nil
+}
+
+func (c *HttpClient) ListAccountSubmissions(ctx context.Context, cursor SubmissionCursor) (SubmissionPage, error) {
+	if err := ctx.Err(); err != nil {
+		return SubmissionPage{}, err
+	}
+	if err := c.requireSession(); err != nil {
+		return SubmissionPage{}, err
+	}
+
+	path := fmt.Sprintf(kAccountSubmissionsPathFormat, cursor.Offset, kSubmissionListPageSize, url.QueryEscape(cursor.LastKey))
+	var m map[string]any
+	if err := c.doJSON(ctx, "account submissions", http.MethodGet, path, kSubmissionsPath, nil, &m); err != nil {
+		return SubmissionPage{}, err
+	}
+
+	dump, ok := m["submissions_dump"].([]any)
+	if !ok {
+		return SubmissionPage{}, fmt.Errorf("leetcode account submissions: missing submissions_dump in response")
+	}
+
+	page := SubmissionPage{HasNext: boolField(m, "has_next")}
+	for _, v := range dump {
+		e, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		sub := Submission{
+			ID:        intField(e, "id"),
+			TitleSlug: stringField(e, "title_slug"),
+			Status:    stringField(e, "status_display"),
+			Lang:      stringField(e, "lang"),
+			Runtime:   stringField(e, "runtime"),
+			Memory:    stringField(e, "memory"),
+		}
+		if sub.ID <= 0 {
+			return SubmissionPage{}, fmt.Errorf("leetcode account submissions: invalid submission id %q", stringField(e, "id"))
+		}
+		if ts := intField(e, "timestamp"); ts > 0 {
+			sub.Time = time.Unix(ts, 0).UTC()
+		}
+		page.Submissions = append(page.Submissions, sub)
+	}
+	page.Next = SubmissionCursor{
+		Offset:  cursor.Offset + len(dump),
+		LastKey: stringField(m, "last_key"),
+	}
+	if len(dump) == 0 {
+		page.HasNext = false
+	}
+	return page, nil
+}
@@ -0,0 +1,181 @@
+package lcx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+func TestHttpClient_ListSubmissions_Pages(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Offset       int    `json:"offset"`
+				Limit        int    `json:"limit"`
+				QuestionSlug string `json:"questionSlug"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if !strings.Contains(body.Query, "questionSubmissionList") || body.Variables.QuestionSlug != "two-sum" {
+			t.Errorf("body = %+v", body)
+		}
+		offsets = append(offsets, body.Variables.Offset)
+
+		var subs []map[string]any
+		for i := 0; i < body.Variables.Limit; i++ {
+			id := 1000 - body.Variables.Offset - i
+			subs = append(subs, map[string]any{
+				"id":            strconv.Itoa(id),
+				"statusDisplay": "Accepted",
+				"lang":          "cpp",
+				"runtime":       "4 ms",
+				"memory":        "10.1 MB",
+				"timestamp":     "1700000000",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"questionSubmissionList": map[string]any{"hasNext": true, "submissions": subs},
+			},
+		})
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	got, err := c.ListSubmissions(context.Background(), "two-sum", ListSubmissionsOptions{Limit: 25})
+	if err != nil {
+		t.Fatalf("ListSubmissions() error = %v", err)
+	}
+	if len(got) != 25 {
+		t.Fatalf("len = %d, want 25", len(got))
+	}
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != 20 {
+		t.Fatalf("offsets = %v, want [0 20]", offsets)
+	}
+	want := Submission{ID: 1000, Status: "Accepted", Lang: "cpp", Runtime: "4 ms", Memory: "10.1 MB", Time: time.Unix(1700000000, 0).UTC()}
+	if got[0] != want {
+		t.Fatalf("got[0] = %+v, want %+v", got[0], want)
+	}
+	if got[24].ID != 976 {
+		t.Fatalf("got[24].ID = %d, want 976", got[24].ID)
+	}
+}
+
+func TestHttpClient_ListSubmissions_GraphQLError(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"questionSubmissionList":null},"errors":[{"message":"User is not logged in"}]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	_, err := c.ListSubmissions(context.Background(), "two-sum", ListSubmissionsOptions{})
+	if err == nil || !strings.Contains(err.Error(), "User is not logged in") {
+		t.Fatalf("ListSubmissions() error = %v, want graphql error message", err)
+	}
+}
+
+func TestHttpClient_FetchSubmission_Sanity(t *testing.T) {
+	t.Parallel()
+
+	var baseURL string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Referer"); got != baseURL+"/submissions/detail/42/" {
+			t.Errorf("Referer = %q", got)
+		}
+		var body struct {
+			Variables struct {
+				SubmissionID int64 `json:"submissionId"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Variables.SubmissionID != 42 {
+			t.Errorf("variables = %+v (err %v)", body.Variables, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"submissionDetails":{"code":"class Solution {};\n","lang":{"name":"cpp"},"question":{"titleSlug":"two-sum"}}}}`))
+	}))
+	t.Cleanup(ts.Close)
+	baseURL = ts.URL
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	got, err := c.FetchSubmission(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("FetchSubmission() error = %v", err)
+	}
+	want := SubmissionDetail{ID: 42, TitleSlug: "two-sum", Lang: "cpp", Code: "class Solution {};\n"}
+	if got != want {
+		t.Fatalf("FetchSubmission() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHttpClient_ListAccountSubmissions_Cursor(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/submissions/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("offset") != "20" || q.Get("lastkey") != "k 1" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"submissions_dump":[{"id":99,"title_slug":"two-sum","status_display":"Accepted",` +
+			`"lang":"cpp","runtime":"0 ms","memory":"9 MB","timestamp":1700000000}],"has_next":true,"last_key":"k2"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewHttpClient(leetcode.NewHttpClient(leetcode.HttpClientOptions{
+		BaseURL: ts.URL,
+		Http:    ts.Client(),
+		Auth:    leetcode.Auth{Session: "sess"},
+	}))
+
+	page, err := c.ListAccountSubmissions(context.Background(), SubmissionCursor{Offset: 20, LastKey: "k 1"})
+	if err != nil {
+		t.Fatalf("ListAccountSubmissions() error = %v", err)
+	}
+	want := Submission{ID: 99, TitleSlug: "two-sum", Status: "Accepted", Lang: "cpp", Runtime: "0 ms", Memory: "9 MB", Time: time.Unix(1700000000, 0).UTC()}
+	if len(page.Submissions) != 1 || page.Submissions[0] != want {
+		t.Fatalf("Submissions = %+v, want [%+v]", page.Submissions, want)
+	}
+	if !page.HasNext || page.Next != (SubmissionCursor{Offset: 21, LastKey: "k2"}) {
+		t.Fatalf("page = %+v", page)
+	}
+}
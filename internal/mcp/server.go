@@ -0,0 +1,212 @@
+// Package mcp is a Model Context Protocol adapter for vleet: JSON-RPC 2.0 over stdio
+// (one message per line) exposing the app's fetch/workspace/submit flows as tools.
+// See docs/v1.1/leetcode-microservice-mcp-analysis.md; the adapter holds no logic of
+// its own and calls the same app.App as the CLI.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"vleet/internal/app"
+	"vleet/internal/output"
+)
+
+const (
+	kProtocolVersion = "2024-11-05"
+	kServerName      = "vleet"
+
+	// kMaxMessageSize bounds a single JSON-RPC line (solutions are small).
+	kMaxMessageSize = 8 << 20
+
+	kJSONRPCVersion = "2.0"
+
+	// JSON-RPC 2.0 error codes.
+	kParseError     = -32700
+	kInvalidRequest = -32600
+	kMethodNotFound = -32601
+	kInvalidParams  = -32602
+)
+
+// Server serves MCP requests for one client.
+type Server struct {
+	App     *app.App
+	Version string
+
+	tools map[string]tool
+}
+
+func NewServer(a *app.App, version string) *Server {
+	s := &Server{App: a, Version: version}
+	s.tools = make(map[string]tool)
+	for _, t := range s.toolList() {
+		s.tools[t.Name] = t
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Serve reads requests from r and writes responses to w until r is exhausted or ctx is
+// canceled. Requests are handled one at a time, in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), kMaxMessageSize)
+	enc := json.NewEncoder(w)
+
+	for sc.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		resp, ok := s.handle(ctx, line)
+		if !ok {
+			continue // notification
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("write mcp response: %w", err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("read mcp request: %w", err)
+	}
+	return nil
+}
+
+// handle returns the response for one message; ok is false for notifications.
+func (s *Server) handle(ctx context.Context, line []byte) (response, bool) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return response{JSONRPC: kJSONRPCVersion, ID: json.RawMessage("null"), Error: &rpcError{Code: kParseError, Message: "parse error: " + err.Error()}}, true
+	}
+	if len(req.ID) == 0 {
+		return response{}, false
+	}
+	resp := response{JSONRPC: kJSONRPCVersion, ID: req.ID}
+	if req.JSONRPC != kJSONRPCVersion || req.Method == "" {
+		resp.Error = &rpcError{Code: kInvalidRequest, Message: "invalid request"}
+		return resp, true
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if err != nil {
+		var re *rpcError
+		if !errors.As(err, &re) {
+			re = &rpcError{Code: kInvalidParams, Message: err.Error()}
+		}
+		resp.Error = re
+		return resp, true
+	}
+	resp.Result = result
+	return resp, true
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": kProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": kServerName, "version": s.Version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		list := s.toolList()
+		descs := make([]map[string]any, 0, len(list))
+		for _, t := range list {
+			descs = append(descs, map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.Schema,
+			})
+		}
+		return map[string]any{"tools": descs}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{Code: kInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+		}
+		t, ok := s.tools[p.Name]
+		if !ok {
+			return nil, &rpcError{Code: kInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+		}
+		if len(p.Arguments) == 0 {
+			p.Arguments = json.RawMessage("{}")
+		}
+		return toolResult(t.Call(ctx, p.Arguments)), nil
+	}
+	return nil, &rpcError{Code: kMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+// toolResult wraps a tool's output as MCP content. Tool failures are results with
+// isError set (not JSON-RPC errors), so the model sees the message.
+func toolResult(v any, err error) map[string]any {
+	if err != nil {
+		return map[string]any{
+			"content": []map[string]any{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}
+	}
+	var text string
+	switch v := v.(type) {
+	case string:
+		text = v
+	case json.RawMessage:
+		text = strings.TrimSpace(string(v))
+	default:
+		b, mErr := json.Marshal(v)
+		if mErr != nil {
+			return toolResult(nil, mErr)
+		}
+		text = string(b)
+	}
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": false,
+	}
+}
+
+// captured runs fn against a copy of the app whose printer writes JSON into a buffer,
+// so app output never reaches the JSON-RPC stream, and returns what was printed.
+func (s *Server) captured(fn func(a *app.App) error) (json.RawMessage, error) {
+	var out, errOut bytes.Buffer
+	a := *s.App
+	a.Output = output.NewStdPrinter(&out, &errOut, true)
+	if err := fn(&a); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(out.Bytes()), nil
+}
@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/app"
+	"vleet/internal/config"
+	"vleet/internal/history"
+	"vleet/internal/output"
+	"vleet/internal/render"
+	"vleet/internal/workspace"
+)
+
+type fakeConfigStore struct{ cfg config.Config }
+
+func (s *fakeConfigStore) Load(ctx context.Context) (config.Config, error) { return s.cfg, nil }
+func (s *fakeConfigStore) Save(ctx context.Context, cfg config.Config) error {
+	s.cfg = cfg
+	return nil
+}
+
+type fakeLeetCode struct {
+	submitted []leetcode.SubmitRequest
+}
+
+func (c *fakeLeetCode) FetchQuestion(ctx context.Context, slug string) (leetcode.Question, error) {
+	return leetcode.Question{
+		QuestionID:   "1",
+		FrontendID:   "1",
+		Title:        "Two Sum",
+		TitleSlug:    slug,
+		Difficulty:   "Easy",
+		ContentHTML:  "<p>Find two numbers.</p>",
+		TopicTags:    []leetcode.TopicTag{{Name: "Array", Slug: "array"}},
+		CodeSnippets: []leetcode.CodeSnippet{{Lang: "C++", LangSlug: "cpp", Code: "class Solution {};"}},
+	}, nil
+}
+
+func (c *fakeLeetCode) Submit(ctx context.Context, req leetcode.SubmitRequest) (leetcode.SubmissionID, error) {
+	c.submitted = append(c.submitted, req)
+	return 42, nil
+}
+
+func (c *fakeLeetCode) PollSubmission(ctx context.Context, id leetcode.SubmissionID, opts leetcode.PollOptions) (leetcode.SubmissionResult, error) {
+	return leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms", Memory: "10.2 MB"}, nil
+}
+
+func newTestServer(t *testing.T) (*Server, *fakeConfigStore, *fakeLeetCode, string) {
+	t.Helper()
+	root := t.TempDir()
+	cs := &fakeConfigStore{cfg: config.Config{
+		DefaultLang: "cpp",
+		LeetCode:    config.LeetCodeAuth{Session: "sess"},
+		Workspace:   config.WorkspaceConfig{Root: root},
+	}}
+	lc := &fakeLeetCode{}
+	a := app.New(app.App{
+		ConfigStore: cs,
+		LeetCode:    lc,
+		Workspace:   workspace.NewFSManager(),
+		Renderer:    render.NewHTMLRenderer(),
+		Output:      output.NewStdPrinter(io.Discard, io.Discard, true),
+		History:     history.NewFileStore(),
+	})
+	return NewServer(a, "test"), cs, lc, root
+}
+
+// transcript sends each request line and returns the responses, keyed by request id.
+func transcript(t *testing.T, s *Server, lines ...string) map[string]response {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	resps := make(map[string]response)
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var raw struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Result  json.RawMessage `json:"result"`
+			Error   *rpcError       `json:"error"`
+		}
+		if err := dec.Decode(&raw); err != nil {
+			t.Fatalf("decode response: %v\n%s", err, out.String())
+		}
+		if raw.JSONRPC != "2.0" {
+			t.Fatalf("jsonrpc = %q", raw.JSONRPC)
+		}
+		resps[string(raw.ID)] = response{ID: raw.ID, Result: raw.Result, Error: raw.Error}
+	}
+	return resps
+}
+
+// toolText returns the text content of a tools/call result and whether it is an error.
+func toolText(t *testing.T, r response) (string, bool) {
+	t.Helper()
+	if r.Error != nil {
+		t.Fatalf("unexpected JSON-RPC error: %+v", r.Error)
+	}
+	var res struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(r.Result.(json.RawMessage), &res); err != nil {
+		t.Fatalf("decode tool result: %v", err)
+	}
+	if len(res.Content) != 1 || res.Content[0].Type != "text" {
+		t.Fatalf("content = %+v, want one text item", res.Content)
+	}
+	return res.Content[0].Text, res.IsError
+}
+
+func TestServer_Transcript_Protocol(t *testing.T) {
+	t.Parallel()
+	s, _, _, _ := newTestServer(t)
+
+	resps := transcript(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope","arguments":{}}}`,
+		`{not json`,
+	)
+
+	if len(resps) != 6 {
+		t.Fatalf("got %d responses, want 6 (no reply to the notification): %v", len(resps), resps)
+	}
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(resps["1"].Result.(json.RawMessage), &init); err != nil {
+		t.Fatalf("decode initialize: %v", err)
+	}
+	if init.ProtocolVersion != "2024-11-05" || init.ServerInfo.Name != "vleet" || init.ServerInfo.Version != "test" {
+		t.Fatalf("initialize = %+v", init)
+	}
+
+	var list struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(resps["2"].Result.(json.RawMessage), &list); err != nil {
+		t.Fatalf("decode tools/list: %v", err)
+	}
+	var names []string
+	for _, tl := range list.Tools {
+		names = append(names, tl.Name)
+		if tl.InputSchema["type"] != "object" {
+			t.Fatalf("tool %s schema = %v", tl.Name, tl.InputSchema)
+		}
+	}
+	if got := strings.Join(names, ","); got != "fetch_question,list_problems,create_workspace,read_solution,submit_solution,get_submission" {
+		t.Fatalf("tools = %s", got)
+	}
+
+	if resps["3"].Error != nil {
+		t.Fatalf("ping error = %+v", resps["3"].Error)
+	}
+	if e := resps["4"].Error; e == nil || e.Code != kMethodNotFound {
+		t.Fatalf("unknown method error = %+v, want %d", e, kMethodNotFound)
+	}
+	if e := resps["5"].Error; e == nil || e.Code != kInvalidParams {
+		t.Fatalf("unknown tool error = %+v, want %d", e, kInvalidParams)
+	}
+	if e := resps["null"].Error; e == nil || e.Code != kParseError {
+		t.Fatalf("parse error = %+v, want %d", e, kParseError)
+	}
+}
+
+func TestServer_Transcript_FetchCreateReadListSubmit(t *testing.T) {
+	t.Parallel()
+	s, cs, lc, root := newTestServer(t)
+
+	resps := transcript(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"fetch_question","arguments":{"problem_key":"two-sum"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"create_workspace","arguments":{"problem_key":"two-sum","lang":"cpp"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"create_workspace","arguments":{"problem_key":"two-sum","lang":"cpp"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"read_solution","arguments":{"problem_key":"two-sum"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"list_problems"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"submit_solution","arguments":{"problem_key":"two-sum"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"read_solution","arguments":{"problem_key":"three-sum"}}}`,
+	)
+
+	text, isErr := toolText(t, resps["1"])
+	var question struct {
+		Schema string          `json:"schema"`
+		Kind   string          `json:"kind"`
+		Data   output.Question `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(text), &question); err != nil || isErr {
+		t.Fatalf("fetch_question = %s (isError=%v, err=%v)", text, isErr, err)
+	}
+	if question.Schema != output.SchemaVersion || question.Kind != output.KindQuestion || question.Data.Title != "Two Sum" ||
+		len(question.Data.CodeSnippets) != 1 || question.Data.CodeSnippets[0].LangSlug != "cpp" {
+		t.Fatalf("fetch_question = %s", text)
+	}
+
+	text, isErr = toolText(t, resps["2"])
+	if isErr || !strings.Contains(text, `"created":true`) {
+		t.Fatalf("create_workspace = %s (isError=%v)", text, isErr)
+	}
+	solution := filepath.Join(root, "two-sum", "solution.cpp")
+	if _, err := os.Stat(solution); err != nil {
+		t.Fatalf("expected %s: %v", solution, err)
+	}
+	if text, _ = toolText(t, resps["3"]); !strings.Contains(text, `"created":false`) {
+		t.Fatalf("second create_workspace = %s, want created=false", text)
+	}
+
+	text, isErr = toolText(t, resps["4"])
+	var read struct {
+		Lang string `json:"lang"`
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(text), &read); err != nil || isErr {
+		t.Fatalf("read_solution = %s (isError=%v, err=%v)", text, isErr, err)
+	}
+	if read.Lang != "cpp" || !strings.Contains(read.Code, "class Solution {};") {
+		t.Fatalf("read_solution = %+v", read)
+	}
+
+	text, isErr = toolText(t, resps["5"])
+	if isErr || !strings.Contains(text, `"slug":"two-sum"`) || !strings.Contains(text, `"langs":["cpp"]`) {
+		t.Fatalf("list_problems = %s (isError=%v)", text, isErr)
+	}
+
+	// Submitting is off unless the config allows it.
+	text, isErr = toolText(t, resps["6"])
+	if !isErr || !strings.Contains(text, "mcp.allow_submit") {
+		t.Fatalf("submit_solution without allow_submit = %s (isError=%v)", text, isErr)
+	}
+	if len(lc.submitted) != 0 {
+		t.Fatalf("expected no submission, got %+v", lc.submitted)
+	}
+
+	if text, isErr = toolText(t, resps["7"]); !isErr {
+		t.Fatalf("read_solution of a missing workspace = %s, want an error", text)
+	}
+
+	cs.cfg.MCP.AllowSubmit = true
+	resps = transcript(t, s,
+		`{"jsonrpc":"2.0","id":"s","method":"tools/call","params":{"name":"submit_solution","arguments":{"problem_key":"two-sum"}}}`,
+		`{"jsonrpc":"2.0","id":"g","method":"tools/call","params":{"name":"get_submission","arguments":{"submission_id":42}}}`,
+	)
+
+	text, isErr = toolText(t, resps[`"s"`])
+	if isErr || !strings.Contains(text, `"Accepted"`) {
+		t.Fatalf("submit_solution = %s (isError=%v)", text, isErr)
+	}
+	if len(lc.submitted) != 1 || lc.submitted[0].Lang != "cpp" || !strings.Contains(lc.submitted[0].TypedCode, "class Solution {};") {
+		t.Fatalf("submitted = %+v", lc.submitted)
+	}
+	if text, isErr = toolText(t, resps[`"g"`]); isErr || !strings.Contains(text, `"Accepted"`) {
+		t.Fatalf("get_submission = %s (isError=%v)", text, isErr)
+	}
+}
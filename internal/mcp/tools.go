@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/app"
+	"vleet/internal/output"
+)
+
+type tool struct {
+	Name        string
+	Description string
+	Schema      map[string]any
+	Call        func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// solutionArgs selects a solution file, as the CLI's <problem-key> --lang --variant.
+type solutionArgs struct {
+	ProblemKey string `json:"problem_key"`
+	Lang       string `json:"lang"`
+	Variant    string `json:"variant"`
+}
+
+func (s *Server) toolList() []tool {
+	solutionSchema := objectSchema(map[string]any{
+		"problem_key": stringProp("LeetCode titleSlug, e.g. two-sum"),
+		"lang":        stringProp("LeetCode language slug, e.g. cpp or python3 (default: inferred from the workspace, else default_lang)"),
+		"variant":     stringProp("optional named variant: solution.<variant>.<ext>"),
+	}, "problem_key")
+
+	return []tool{
+		{
+			Name:        "fetch_question",
+			Description: "Fetch a LeetCode problem: title, difficulty, tags, statement (HTML), example testcases and the starter code per language, as a vleet/v1 question envelope.",
+			Schema:      objectSchema(map[string]any{"problem_key": stringProp("LeetCode titleSlug, e.g. two-sum")}, "problem_key"),
+			Call:        s.fetchQuestion,
+		},
+		{
+			Name:        "list_problems",
+			Description: "List the local problem workspaces and the languages solved in each.",
+			Schema:      objectSchema(map[string]any{}),
+			Call:        s.listProblems,
+		},
+		{
+			Name:        "create_workspace",
+			Description: "Create the workspace for a problem with a starter solution file (never overwrites an existing solution).",
+			Schema:      solutionSchema,
+			Call:        s.createWorkspace,
+		},
+		{
+			Name:        "read_solution",
+			Description: "Read the current solution file of a problem.",
+			Schema:      solutionSchema,
+			Call:        s.readSolution,
+		},
+		{
+			Name:        "submit_solution",
+			Description: "Submit the solution file to LeetCode and wait for the verdict. Requires mcp.allow_submit in the vleet config.",
+			Schema:      solutionSchema,
+			Call:        s.submitSolution,
+		},
+		{
+			Name:        "get_submission",
+			Description: "Get the verdict of a submission by ID (waits until it is judged).",
+			Schema:      objectSchema(map[string]any{"submission_id": map[string]any{"type": "integer", "description": "LeetCode submission ID"}}, "submission_id"),
+			Call:        s.getSubmission,
+		},
+	}
+}
+
+func (s *Server) fetchQuestion(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args solutionArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.ProblemKey) == "" {
+		return nil, fmt.Errorf("problem_key is required")
+	}
+	if s.App.LeetCode == nil {
+		return nil, fmt.Errorf("leetcode client is not configured")
+	}
+
+	q, err := s.App.LeetCode.FetchQuestion(ctx, args.ProblemKey)
+	if err != nil {
+		return nil, err
+	}
+	return output.Envelope{Schema: output.SchemaVersion, Kind: output.KindQuestion, Data: output.NewQuestion(q)}, nil
+}
+
+func (s *Server) listProblems(ctx context.Context, raw json.RawMessage) (any, error) {
+	return s.App.ListProblems(ctx)
+}
+
+func (s *Server) createWorkspace(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args solutionArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	ws, created, err := s.App.CreateWorkspace(ctx, app.FetchOptions{ProblemKey: args.ProblemKey, Lang: args.Lang, Variant: args.Variant})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"dir": ws.Dir, "solution_path": ws.SolutionPath, "lang": ws.Lang, "created": created}, nil
+}
+
+func (s *Server) readSolution(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args solutionArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	ws, code, err := s.App.ReadSolution(ctx, app.ReadSolutionOptions{ProblemKey: args.ProblemKey, Lang: args.Lang, Variant: args.Variant})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"solution_path": ws.SolutionPath, "lang": ws.Lang, "code": code}, nil
+}
+
+func (s *Server) submitSolution(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args solutionArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if s.App.ConfigStore == nil {
+		return nil, fmt.Errorf("config store is not configured")
+	}
+	cfg, err := s.App.ConfigStore.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.MCP.AllowSubmit {
+		return nil, fmt.Errorf("submitting is disabled for MCP clients (set mcp.allow_submit: true in the vleet config to allow it)")
+	}
+
+	return s.captured(func(a *app.App) error {
+		return a.Submit(ctx, app.SubmitOptions{ProblemKey: args.ProblemKey, Lang: args.Lang, Variant: args.Variant})
+	})
+}
+
+func (s *Server) getSubmission(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		SubmissionID int64 `json:"submission_id"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	return s.captured(func(a *app.App) error {
+		return a.Status(ctx, app.StatusOptions{SubmissionID: leetcode.SubmissionID(args.SubmissionID)})
+	})
+}
+
+func decodeArgs(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func stringProp(desc string) map[string]any {
+	return map[string]any{"type": "string", "description": desc}
+}
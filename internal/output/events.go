@@ -0,0 +1,110 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event kinds reported while a submission is judged.
+const (
+	EventSubmitted = "submitted"
+	EventPolling   = "polling"
+	EventResult    = "result"
+	EventError     = "error"
+)
+
+// Event is one step of a submit: submitted (ID known), polling (one check that is still
+// being judged), then result or error.
+type Event struct {
+	Kind         string `json:"event"`
+	SubmissionID int64  `json:"submission_id,omitempty"`
+
+	// State and Attempt are set for polling events.
+	State   string `json:"state,omitempty"`
+	Attempt int    `json:"attempt,omitempty"`
+
+	Result *Verdict `json:"result,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// PrintEvent writes ev as one NDJSON line (an Envelope of kind "event") on Out.
+func (p *StdPrinter) PrintEvent(ctx context.Context, ev Event) {
+	_ = writeEnvelope(p.Out, KindEvent, ev)
+}
+
+// kSpinnerFrames are drawn in turn, one per kSpinnerInterval.
+var kSpinnerFrames = []string{"|", "/", "-", "\\"}
+
+const kSpinnerInterval = 120 * time.Millisecond
+
+// Spinner draws the state of a submission on one terminal line (stderr) until the result
+// or an error arrives, then clears the line.
+type Spinner struct {
+	W io.Writer
+
+	mu    sync.Mutex
+	label string
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func NewSpinner(w io.Writer) *Spinner { return &Spinner{W: w} }
+
+// Event updates the spinner; pass it as the app's progress callback.
+func (s *Spinner) Event(ctx context.Context, ev Event) {
+	switch ev.Kind {
+	case EventSubmitted:
+		s.start(fmt.Sprintf("Submitted %d, waiting for verdict", ev.SubmissionID))
+	case EventPolling:
+		s.start(fmt.Sprintf("Judging: %s (check %d)", ev.State, ev.Attempt))
+	case EventResult, EventError:
+		s.Stop()
+	}
+}
+
+func (s *Spinner) start(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.label = label
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+func (s *Spinner) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(kSpinnerInterval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		s.mu.Lock()
+		label := s.label
+		s.mu.Unlock()
+		_, _ = fmt.Fprintf(s.W, "\r\033[K%s %s", kSpinnerFrames[frame%len(kSpinnerFrames)], label)
+
+		select {
+		case <-stop:
+			_, _ = fmt.Fprint(s.W, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop clears the spinner line. It is safe to call when the spinner is not running.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSpinner_ShowsStateAndClearsOnResult(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	s := NewSpinner(&buf)
+	s.Event(context.Background(), Event{Kind: EventSubmitted, SubmissionID: 7})
+	s.Event(context.Background(), Event{Kind: EventPolling, SubmissionID: 7, State: "PENDING", Attempt: 1})
+	s.Stop()
+	s.Event(context.Background(), Event{Kind: EventPolling, SubmissionID: 7, State: "STARTED", Attempt: 2})
+	s.Event(context.Background(), Event{Kind: EventResult, SubmissionID: 7})
+
+	got := buf.String()
+	if !strings.Contains(got, "Judging: STARTED (check 2)") {
+		t.Fatalf("expected the current state, got %q", got)
+	}
+	if !strings.HasSuffix(got, "\r\033[K") {
+		t.Fatalf("expected the line to be cleared at the end, got %q", got)
+	}
+
+	s.Stop() // no-op when not running
+}
@@ -2,11 +2,15 @@ package output
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
 )
 
 // Printer renders user-facing output (human and/or JSON).
@@ -14,6 +18,10 @@ import (
 type Printer interface {
 	PrintQuestion(ctx context.Context, q leetcode.Question) error
 	PrintSubmissionResult(ctx context.Context, r leetcode.SubmissionResult) error
+	PrintRunResult(ctx context.Context, r lcx.RunResult) error
+	PrintSubmissions(ctx context.Context, subs []lcx.Submission) error
+	PrintLangs(ctx context.Context, langs []LangStatus) error
+	PrintCheckResults(ctx context.Context, results []CheckResult) error
 	PrintError(ctx context.Context, err error) error
 }
 
@@ -23,6 +31,18 @@ type StdPrinter struct {
 	Out  io.Writer
 	Err  io.Writer
 	JSON bool
+
+	// ErrorFormat prints compile/runtime errors as "file:line:col: message" lines on Out
+	// (for Vim's :cfile / :make); the verdict summary goes to Err. Ignored with JSON.
+	ErrorFormat bool
+
+	// Source maps LeetCode error line numbers back to the solution file (set by the app
+	// before printing a result).
+	Source SourceMap
+
+	// Events streams a submit as NDJSON events (see PrintEvent). The verdict then arrives
+	// as the result event, so PrintSubmissionResult prints nothing.
+	Events bool
 }
 
 func NewStdPrinter(out io.Writer, err io.Writer, asJSON bool) *StdPrinter {
@@ -31,15 +51,25 @@ func NewStdPrinter(out io.Writer, err io.Writer, asJSON bool) *StdPrinter {
 
 func (p *StdPrinter) PrintQuestion(ctx context.Context, q leetcode.Question) error {
 	if p.JSON {
-		return json.NewEncoder(p.Out).Encode(q)
+		return writeEnvelope(p.Out, KindQuestion, NewQuestion(q))
 	}
 	_, err := fmt.Fprintf(p.Out, "%s (%s)\n", q.Title, q.Difficulty)
 	return err
 }
 
 func (p *StdPrinter) PrintSubmissionResult(ctx context.Context, r leetcode.SubmissionResult) error {
+	if p.Events {
+		return nil
+	}
 	if p.JSON {
-		return json.NewEncoder(p.Out).Encode(r)
+		return writeEnvelope(p.Out, KindVerdict, NewVerdict(r))
+	}
+	if p.ErrorFormat {
+		status := r.Status
+		if status == "" {
+			status = r.State
+		}
+		return p.printQuickfix("Verdict: "+status, r.CompileError, r.RuntimeError)
 	}
 
 	if r.Status != "" {
@@ -89,10 +119,237 @@ func (p *StdPrinter) PrintSubmissionResult(ctx context.Context, r leetcode.Submi
 	return nil
 }
 
+func (p *StdPrinter) PrintRunResult(ctx context.Context, r lcx.RunResult) error {
+	if p.JSON {
+		return writeEnvelope(p.Out, KindRunResult, NewRunResult(r))
+	}
+
+	status := r.Status
+	if status == "" {
+		status = r.State
+	}
+	if p.ErrorFormat {
+		return p.printQuickfix("Run: "+status, r.CompileError, r.RuntimeError)
+	}
+	if r.TotalTestcases > 0 && r.CompileError == "" && r.RuntimeError == "" {
+		verdict := "Wrong Answer"
+		if r.Correct {
+			verdict = "Accepted"
+		}
+		if _, err := fmt.Fprintf(p.Out, "Run: %s (%d/%d testcases passed)\n", verdict, r.TotalCorrect, r.TotalTestcases); err != nil {
+			return err
+		}
+	} else if status != "" {
+		if _, err := fmt.Fprintf(p.Out, "Run: %s\n", status); err != nil {
+			return err
+		}
+	}
+
+	if r.Runtime != "" {
+		if _, err := fmt.Fprintf(p.Out, "Runtime: %s\n", r.Runtime); err != nil {
+			return err
+		}
+	}
+
+	for i := range r.CodeAnswer {
+		expected := ""
+		if i < len(r.ExpectedAnswer) {
+			expected = r.ExpectedAnswer[i]
+		}
+		mark := "ok  "
+		if r.CodeAnswer[i] != expected {
+			mark = "FAIL"
+		}
+		if _, err := fmt.Fprintf(p.Out, "  [%s] case %d: output=%s expected=%s\n", mark, i+1, r.CodeAnswer[i], expected); err != nil {
+			return err
+		}
+	}
+
+	if r.CompileError != "" {
+		if _, err := fmt.Fprintln(p.Out); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(p.Out, "Compile Error:"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(p.Out, r.CompileError); err != nil {
+			return err
+		}
+	}
+	if r.RuntimeError != "" {
+		if _, err := fmt.Fprintln(p.Out); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(p.Out, "Runtime Error:"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(p.Out, r.RuntimeError); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *StdPrinter) PrintSubmissions(ctx context.Context, subs []lcx.Submission) error {
+	if p.JSON {
+		if subs == nil {
+			subs = []lcx.Submission{}
+		}
+		return writeEnvelope(p.Out, KindSubmissions, subs)
+	}
+	if len(subs) == 0 {
+		_, err := fmt.Fprintln(p.Out, "No submissions.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.Out, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ID\tSTATUS\tLANG\tRUNTIME\tMEMORY\tTIME"); err != nil {
+		return err
+	}
+	for _, s := range subs {
+		when := ""
+		if !s.Time.IsZero() {
+			when = s.Time.Local().Format("2006-01-02 15:04")
+		}
+		if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", s.ID, s.Status, s.Lang, s.Runtime, s.Memory, when); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// LangStatus is one language present in a workspace, with its most recent verdict from
+// the workspace history.
+type LangStatus struct {
+	Lang string `json:"lang"`
+
+	// Files are the solution files (default first, then variants) relative to the workspace dir.
+	Files []string `json:"files"`
+
+	LastVerdict string    `json:"last_verdict,omitempty"`
+	LastTime    time.Time `json:"last_time,omitzero"`
+}
+
+func (p *StdPrinter) PrintLangs(ctx context.Context, langs []LangStatus) error {
+	if p.JSON {
+		if langs == nil {
+			langs = []LangStatus{}
+		}
+		return writeEnvelope(p.Out, KindLangs, langs)
+	}
+	if len(langs) == 0 {
+		_, err := fmt.Fprintln(p.Out, "No solution files.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.Out, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "LANG\tFILES\tLAST VERDICT\tTIME"); err != nil {
+		return err
+	}
+	for _, l := range langs {
+		when := ""
+		if !l.LastTime.IsZero() {
+			when = l.LastTime.Local().Format("2006-01-02 15:04")
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", l.Lang, strings.Join(l.Files, ", "), l.LastVerdict, when); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// CheckResult is one solution checked by check-all: its examples run, or a re-submit.
+type CheckResult struct {
+	Slug string `json:"slug"`
+	Lang string `json:"lang"`
+
+	// Mode is "run" or "submit".
+	Mode   string `json:"mode"`
+	Passed bool   `json:"passed"`
+
+	// Status is the verdict, or "Error" when the check did not complete (see Error).
+	Status         string `json:"status"`
+	SubmissionID   int64  `json:"submission_id,omitempty"`
+	TotalCorrect   int    `json:"total_correct,omitempty"`
+	TotalTestcases int    `json:"total_testcases,omitempty"`
+	Runtime        string `json:"runtime,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+func (p *StdPrinter) PrintCheckResults(ctx context.Context, results []CheckResult) error {
+	if p.JSON {
+		if results == nil {
+			results = []CheckResult{}
+		}
+		return writeEnvelope(p.Out, KindCheckResults, results)
+	}
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(p.Out, "No solutions to check.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.Out, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "PROBLEM\tLANG\tMODE\tSTATUS\tDETAIL"); err != nil {
+		return err
+	}
+	passed := 0
+	for _, r := range results {
+		if r.Passed {
+			passed++
+		}
+		detail := r.Error
+		switch {
+		case detail != "":
+		case r.Mode == "run" && r.TotalTestcases > 0:
+			detail = fmt.Sprintf("%d/%d examples", r.TotalCorrect, r.TotalTestcases)
+		case r.SubmissionID > 0:
+			detail = fmt.Sprintf("submission %d", r.SubmissionID)
+		}
+		if r.Runtime != "" {
+			detail = strings.TrimPrefix(detail+", "+r.Runtime, ", ")
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Slug, r.Lang, r.Mode, r.Status, detail); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(p.Out, "\n%d passed, %d failed\n", passed, len(results)-passed)
+	return err
+}
+
+func (p *StdPrinter) printQuickfix(summary string, errTexts ...string) error {
+	if _, err := fmt.Fprintln(p.Err, summary); err != nil {
+		return err
+	}
+	for _, text := range errTexts {
+		if text == "" {
+			continue
+		}
+		for _, line := range Quickfix(p.Source, text) {
+			if _, err := fmt.Fprintln(p.Out, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (p *StdPrinter) PrintError(ctx context.Context, err error) error {
 	if err == nil {
 		return nil
 	}
-	_, werr := fmt.Fprintf(p.Err, "error: %v\n", err)
-	return werr
+	if p.JSON {
+		return writeEnvelope(p.Err, KindError, NewErrorData(err))
+	}
+	if _, werr := fmt.Fprintf(p.Err, "error: %v\n", err); werr != nil {
+		return werr
+	}
+	if hint := errx.Hint(err); hint != "" {
+		_, werr := fmt.Fprintf(p.Err, "hint: %s\n", hint)
+		return werr
+	}
+	return nil
 }
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SourceMap maps line numbers in the code sent to LeetCode back to the solution file,
+// which is sent verbatim.
+type SourceMap struct {
+	// Path is the solution file as shown in quickfix output.
+	Path string
+
+	// FileLines is the number of lines in the solution file; submitted lines past it
+	// (e.g. in LeetCode's wrapper or library headers) don't map.
+	FileLines int
+}
+
+func NewSourceMap(path string, fileLines int) SourceMap {
+	return SourceMap{Path: path, FileLines: fileLines}
+}
+
+// FileLine maps a submitted line number to a file line number.
+func (m SourceMap) FileLine(n int) (int, bool) {
+	return n, n > 0 && n <= m.FileLines
+}
+
+var (
+	// clang/gcc as reported by LeetCode: "Line 5: Char 9: error: use of undeclared identifier 'x'".
+	reLeetCodeLineChar = regexp.MustCompile(`^Line (\d+): Char (\d+): (?:(error|warning|note|runtime error): )?(.*)$`)
+	// Other compilers/runtimes: "Line 5: SyntaxError: ..." or "... (Solution.py, line 5)".
+	reLeetCodeLine = regexp.MustCompile(`^Line (\d+): (.*)$`)
+	reTrailingLine = regexp.MustCompile(`(?i)\bline (\d+)\b`)
+)
+
+// Quickfix converts a LeetCode compile/runtime error into "file:line:col: message" lines
+// that Vim's :cfile / :make understand (default 'errorformat'). Lines that can't be tied
+// to a location in the solution file (e.g. code excerpts, caret markers, STL internals)
+// are dropped; if nothing maps, the first error line is returned without a location.
+func Quickfix(m SourceMap, errText string) []string {
+	var out []string
+	var first string
+
+	for _, raw := range strings.Split(errText, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if first == "" {
+			first = line
+		}
+
+		var n, col int
+		var sev, msg string
+		if sm := reLeetCodeLineChar.FindStringSubmatch(line); sm != nil {
+			n, _ = strconv.Atoi(sm[1])
+			col, _ = strconv.Atoi(sm[2])
+			sev, msg = sm[3], sm[4]
+		} else if sm := reLeetCodeLine.FindStringSubmatch(line); sm != nil {
+			n, _ = strconv.Atoi(sm[1])
+			msg = sm[2]
+		} else if sm := reTrailingLine.FindStringSubmatch(line); sm != nil && !strings.Contains(line, "|") {
+			n, _ = strconv.Atoi(sm[1])
+			msg = line
+		} else {
+			continue
+		}
+
+		fileLine, ok := m.FileLine(n)
+		if !ok {
+			continue
+		}
+		if col <= 0 {
+			col = 1
+		}
+		if sev == "" {
+			sev = "error"
+		}
+		out = append(out, fmt.Sprintf("%s:%d:%d: %s: %s", m.Path, fileLine, col, sev, msg))
+	}
+
+	if len(out) == 0 && first != "" {
+		return []string{first}
+	}
+	return out
+}
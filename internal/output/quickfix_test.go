@@ -0,0 +1,50 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuickfix_ClangStyle_KeepsLinesAndDropsExcerpts(t *testing.T) {
+	t.Parallel()
+
+	ce := "Line 5: Char 9: error: use of undeclared identifier 'x'\n" +
+		"    5 |         x = 1;\n" +
+		"      |         ^\n" +
+		"Line 7: Char 1: warning: unused variable 'y'\n" +
+		"1 error generated."
+
+	m := NewSourceMap("two-sum/solution.cpp", 20)
+
+	got := Quickfix(m, ce)
+	want := []string{
+		"two-sum/solution.cpp:5:9: error: use of undeclared identifier 'x'",
+		"two-sum/solution.cpp:7:1: warning: unused variable 'y'",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Quickfix() = %q, want %q", got, want)
+	}
+}
+
+func TestQuickfix_IdentityMap_PythonAndUnmapped(t *testing.T) {
+	t.Parallel()
+
+	m := NewSourceMap("two-sum/solution.py", 10)
+
+	got := Quickfix(m, "Line 4: IndentationError: expected an indented block")
+	if want := []string{"two-sum/solution.py:4:1: error: IndentationError: expected an indented block"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Quickfix() = %q, want %q", got, want)
+	}
+
+	// Past the end of a verbatim file is LeetCode's wrapper or the STL, not the solution.
+	got = Quickfix(m, "Line 1034: Char 9: runtime error: reference binding to null pointer (stl_vector.h)")
+	if want := []string{"Line 1034: Char 9: runtime error: reference binding to null pointer (stl_vector.h)"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Quickfix() = %q, want %q", got, want)
+	}
+	if _, ok := m.FileLine(11); ok {
+		t.Fatalf("FileLine(11) mapped in a 10-line file")
+	}
+	if _, ok := m.FileLine(0); ok {
+		t.Fatalf("FileLine(0) mapped")
+	}
+}
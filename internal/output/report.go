@@ -0,0 +1,317 @@
+package output
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
+)
+
+// Report formats.
+const (
+	ReportJUnit = "junit"
+	ReportTAP   = "tap"
+)
+
+// Reporter receives run and submit results next to the Printer, e.g. to write a CI
+// report once the command is done.
+type Reporter interface {
+	// AddRun records a run of the examples in input (newline-separated, as sent to LeetCode).
+	AddRun(problemKey string, lang string, input string, r lcx.RunResult)
+	AddSubmission(problemKey string, lang string, id int64, r lcx.SubmissionResult)
+}
+
+// Report collects results as test suites, one per problem (sorted by slug), with one
+// testcase per example or submission, and writes them as JUnit XML or TAP. It is safe
+// for concurrent use.
+type Report struct {
+	Format string // ReportJUnit or ReportTAP
+
+	// Path is the report file; "" means stdout.
+	Path string
+
+	mu     sync.Mutex
+	suites []*reportSuite
+}
+
+type reportSuite struct {
+	name  string
+	cases []reportCase
+}
+
+type reportCase struct {
+	name string
+
+	// failure is the short reason (e.g. "Wrong Answer"); "" means the case passed.
+	failure string
+	details string
+
+	// errored marks a case that could not be checked at all (see AddError).
+	errored bool
+}
+
+// ParseReport parses a --report value: "junit=path.xml", "tap=path.tap", or a bare
+// format to write to stdout.
+func ParseReport(spec string) (*Report, error) {
+	format, path, _ := strings.Cut(strings.TrimSpace(spec), "=")
+	switch format {
+	case ReportJUnit, ReportTAP:
+	default:
+		return nil, fmt.Errorf("unknown report format %q (want %s or %s, optionally =<path>)", format, ReportJUnit, ReportTAP)
+	}
+	return &Report{Format: format, Path: strings.TrimSpace(path)}, nil
+}
+
+func (r *Report) AddRun(problemKey string, lang string, input string, res lcx.RunResult) {
+	inputs := splitInputs(input, max(len(res.ExpectedAnswer), res.TotalTestcases))
+
+	var cases []reportCase
+	if res.CompileError != "" || len(inputs) == 0 {
+		c := reportCase{name: lang + " examples"}
+		if res.CompileError != "" || !res.Correct {
+			c.failure = runStatus(res)
+			c.details = joinDetails("input", input, "compile error", res.CompileError, "runtime error", res.RuntimeError)
+		}
+		cases = append(cases, c)
+	}
+	for i := 0; res.CompileError == "" && i < len(inputs); i++ {
+		expected, actual, stdout := at(res.ExpectedAnswer, i), at(res.CodeAnswer, i), at(res.StdOutput, i)
+		c := reportCase{name: fmt.Sprintf("%s example %d", lang, i+1)}
+		switch {
+		case i >= len(res.CodeAnswer):
+			// Execution stopped before this case (e.g. a runtime error in an earlier one).
+			c.failure = runStatus(res)
+			c.details = joinDetails("input", inputs[i], "expected", expected, "runtime error", res.RuntimeError)
+		case actual != expected:
+			c.failure = "Wrong Answer"
+			c.details = joinDetails("input", inputs[i], "expected", expected, "actual", actual, "stdout", stdout)
+		}
+		cases = append(cases, c)
+	}
+	r.add(problemKey, cases...)
+}
+
+func (r *Report) AddSubmission(problemKey string, lang string, id int64, res lcx.SubmissionResult) {
+	c := reportCase{name: fmt.Sprintf("%s submission %d", lang, id)}
+	if res.Status != "Accepted" {
+		c.failure = res.Status
+		if c.failure == "" {
+			c.failure = res.State
+		}
+		c.details = joinDetails(
+			"input", res.LastTestcase, "expected", res.ExpectedOutput, "actual", res.CodeOutput, "stdout", res.StdOutput,
+			"compile error", res.CompileError, "runtime error", res.RuntimeError,
+		)
+	}
+	r.add(problemKey, c)
+}
+
+// AddError records err, the error the command failed with, as an errored testcase named
+// after the command, so a report for a command that failed before (or while) collecting
+// results is never empty and never green. Rejected verdicts are already in the report as
+// failures and are not recorded again.
+func (r *Report) AddError(command string, err error) {
+	if err == nil || errors.Is(err, errx.ErrNotAccepted) {
+		return
+	}
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	details := err.Error()
+	if hint := errx.Hint(err); hint != "" {
+		details += "\nhint: " + hint
+	}
+	r.add("vleet", reportCase{name: command, failure: msg, details: details, errored: true})
+}
+
+func (r *Report) add(problemKey string, cases ...reportCase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.suites {
+		if s.name == problemKey {
+			s.cases = append(s.cases, cases...)
+			return
+		}
+	}
+	r.suites = append(r.suites, &reportSuite{name: problemKey, cases: cases})
+}
+
+// Write writes the report to Path, or to stdout when Path is "".
+func (r *Report) Write(stdout io.Writer) error {
+	if r.Path == "" {
+		return r.write(stdout)
+	}
+	f, err := os.Create(r.Path)
+	if err != nil {
+		return fmt.Errorf("write %s report: %w", r.Format, err)
+	}
+	if err := r.write(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write %s report: %w", r.Format, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s report: %w", r.Format, err)
+	}
+	return nil
+}
+
+func (r *Report) write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Concurrent checks finish in any order; keep reports stable across runs.
+	sort.SliceStable(r.suites, func(i, j int) bool { return r.suites[i].name < r.suites[j].name })
+	if r.Format == ReportTAP {
+		return r.writeTAP(w)
+	}
+	return r.writeJUnit(w)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Details string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	doc := junitSuites{Name: "vleet", Suites: []junitSuite{}}
+	for _, s := range r.suites {
+		js := junitSuite{Name: s.name, Tests: len(s.cases)}
+		for _, c := range s.cases {
+			jc := junitCase{Name: c.name, ClassName: s.name}
+			switch {
+			case c.errored:
+				jc.Error = &junitFailure{Message: c.failure, Details: c.details}
+				js.Errors++
+			case c.failure != "":
+				jc.Failure = &junitFailure{Message: c.failure, Details: c.details}
+				js.Failures++
+			}
+			js.Cases = append(js.Cases, jc)
+		}
+		doc.Tests += js.Tests
+		doc.Failures += js.Failures
+		doc.Errors += js.Errors
+		doc.Suites = append(doc.Suites, js)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeTAP writes TAP version 13: one test per case named "<problem>: <case>", with a
+// YAML diagnostic block for failures and errors.
+func (r *Report) writeTAP(w io.Writer) error {
+	var b strings.Builder
+	total := 0
+	for _, s := range r.suites {
+		total += len(s.cases)
+	}
+	fmt.Fprintf(&b, "TAP version 13\n1..%d\n", total)
+
+	n := 0
+	for _, s := range r.suites {
+		for _, c := range s.cases {
+			n++
+			if c.failure == "" {
+				fmt.Fprintf(&b, "ok %d - %s: %s\n", n, s.name, c.name)
+				continue
+			}
+			fmt.Fprintf(&b, "not ok %d - %s: %s\n", n, s.name, c.name)
+			fmt.Fprintf(&b, "  ---\n  message: %q\n", c.failure)
+			if c.details != "" {
+				b.WriteString("  details: |-\n")
+				for _, line := range strings.Split(c.details, "\n") {
+					fmt.Fprintf(&b, "    %s\n", line)
+				}
+			}
+			b.WriteString("  ...\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// splitInputs splits a run's input into one chunk per testcase: LeetCode sends each case
+// as the same number of lines. It returns the whole input for every case when the lines
+// don't divide evenly, and nil without cases.
+func splitInputs(input string, cases int) []string {
+	if cases <= 0 {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(input, "\n"), "\n")
+	out := make([]string, cases)
+	per := len(lines) / cases
+	for i := range out {
+		if per == 0 || len(lines)%cases != 0 {
+			out[i] = strings.TrimRight(input, "\n")
+			continue
+		}
+		out[i] = strings.Join(lines[i*per:(i+1)*per], "\n")
+	}
+	return out
+}
+
+func runStatus(r lcx.RunResult) string {
+	switch {
+	case r.Status != "":
+		return r.Status
+	case r.State != "":
+		return r.State
+	}
+	return "Failed"
+}
+
+// joinDetails formats label/value pairs as "label:\n<value>" paragraphs, skipping
+// empty values.
+func joinDetails(pairs ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if v := strings.TrimRight(pairs[i+1], "\n"); v != "" {
+			parts = append(parts, pairs[i]+":\n"+v)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func at(s []string, i int) string {
+	if i < len(s) {
+		return s[i]
+	}
+	return ""
+}
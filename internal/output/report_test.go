@@ -0,0 +1,227 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+	"vleet/internal/lcx"
+)
+
+func newTestReport(t *testing.T, format string) *Report {
+	t.Helper()
+	r, err := ParseReport(format)
+	if err != nil {
+		t.Fatalf("ParseReport(%q) error = %v", format, err)
+	}
+	// Two examples of two lines each; the second one fails.
+	r.AddRun("two-sum", "cpp", "[2,7,11,15]\n9\n[3,2,4]\n6\n", lcx.RunResult{
+		State: "SUCCESS", Status: "Accepted", TotalTestcases: 2, TotalCorrect: 1,
+		CodeAnswer: []string{"[0,1]", "[0,2]"}, ExpectedAnswer: []string{"[0,1]", "[1,2]"}, StdOutput: []string{"", "dbg"},
+	})
+	r.AddSubmission("two-sum", "cpp", 42, lcx.SubmissionResult{SubmissionResult: leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted"}})
+	r.AddSubmission("add-two-numbers", "cpp", 43, lcx.SubmissionResult{SubmissionResult: leetcode.SubmissionResult{State: "SUCCESS", Status: "Compile Error", CompileError: "line 3: expected ';'"}})
+	return r
+}
+
+func TestReport_JUnit(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	if err := newTestReport(t, "junit").Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var doc junitSuites
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("report is not XML: %v\n%s", err, out.String())
+	}
+	if doc.Tests != 4 || doc.Failures != 2 || len(doc.Suites) != 2 {
+		t.Fatalf("testsuites: tests=%d failures=%d suites=%d\n%s", doc.Tests, doc.Failures, len(doc.Suites), out.String())
+	}
+	twoSum := doc.Suites[1]
+	if twoSum.Name != "two-sum" || twoSum.Tests != 3 || twoSum.Failures != 1 {
+		t.Fatalf("two-sum suite = %+v", twoSum)
+	}
+	if c := twoSum.Cases[0]; c.Name != "cpp example 1" || c.Failure != nil {
+		t.Fatalf("example 1 = %+v", c)
+	}
+	f := twoSum.Cases[1].Failure
+	if f == nil || f.Message != "Wrong Answer" {
+		t.Fatalf("example 2 failure = %+v", f)
+	}
+	for _, want := range []string{"input:\n[3,2,4]\n6", "expected:\n[1,2]", "actual:\n[0,2]", "stdout:\ndbg"} {
+		if !strings.Contains(f.Details, want) {
+			t.Fatalf("failure details missing %q:\n%s", want, f.Details)
+		}
+	}
+	if c := doc.Suites[0].Cases[0]; c.Name != "cpp submission 43" || c.Failure == nil || c.Failure.Message != "Compile Error" || !strings.Contains(c.Failure.Details, "expected ';'") {
+		t.Fatalf("submission case = %+v", c)
+	}
+}
+
+func TestReport_TAP(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "report.tap")
+	r := newTestReport(t, "tap="+path)
+	if err := r.Write(nil); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+
+	want := `TAP version 13
+1..4
+not ok 1 - add-two-numbers: cpp submission 43
+  ---
+  message: "Compile Error"
+  details: |-
+    compile error:
+    line 3: expected ';'
+  ...
+ok 2 - two-sum: cpp example 1
+not ok 3 - two-sum: cpp example 2
+  ---
+  message: "Wrong Answer"
+  details: |-
+    input:
+    [3,2,4]
+    6
+    expected:
+    [1,2]
+    actual:
+    [0,2]
+    stdout:
+    dbg
+  ...
+ok 4 - two-sum: cpp submission 42
+`
+	if string(b) != want {
+		t.Fatalf("TAP report:\n%s\nwant:\n%s", b, want)
+	}
+}
+
+func TestReport_RunWithoutPerCaseResults(t *testing.T) {
+	t.Parallel()
+
+	r := &Report{Format: ReportTAP}
+	r.AddRun("two-sum", "cpp", "[2,7]\n9\n", lcx.RunResult{State: "SUCCESS", Status: "Compile Error", CompileError: "oops"})
+	// A runtime error in the first of two cases leaves the second unanswered.
+	r.AddRun("two-sum", "cpp", "[2,7]\n9\n[3,3]\n6\n", lcx.RunResult{
+		State: "SUCCESS", Status: "Runtime Error", TotalTestcases: 2, RuntimeError: "overflow",
+		CodeAnswer: []string{"[0,1]"}, ExpectedAnswer: []string{"[0,1]", "[0,1]"},
+	})
+
+	var out bytes.Buffer
+	if err := r.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"not ok 1 - two-sum: cpp examples\n",
+		"    compile error:\n    oops\n",
+		"ok 2 - two-sum: cpp example 1\n",
+		"not ok 3 - two-sum: cpp example 2\n  ---\n  message: \"Runtime Error\"\n",
+		"    input:\n    [3,3]\n    6\n",
+		"    runtime error:\n    overflow\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("report missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestReport_SubmissionFailingTestcase(t *testing.T) {
+	t.Parallel()
+
+	r := &Report{Format: ReportTAP}
+	r.AddSubmission("two-sum", "cpp", 44, lcx.SubmissionResult{
+		SubmissionResult: leetcode.SubmissionResult{State: "SUCCESS", Status: "Wrong Answer"},
+		LastTestcase:     "[3,2,4]\n6", ExpectedOutput: "[1,2]", CodeOutput: "[0,2]",
+	})
+
+	var out bytes.Buffer
+	if err := r.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := "not ok 1 - two-sum: cpp submission 44\n  ---\n  message: \"Wrong Answer\"\n  details: |-\n" +
+		"    input:\n    [3,2,4]\n    6\n    expected:\n    [1,2]\n    actual:\n    [0,2]\n  ...\n"
+	if !strings.HasSuffix(out.String(), want) {
+		t.Fatalf("report:\n%s\nwant suffix:\n%s", out.String(), want)
+	}
+}
+
+func TestReport_CommandError(t *testing.T) {
+	t.Parallel()
+
+	// The command failed before any result arrived.
+	authErr := errx.Mark(errors.New("not logged in"), errx.ErrAuth)
+	r := &Report{Format: ReportJUnit}
+	r.AddError("submit", authErr)
+
+	var out bytes.Buffer
+	if err := r.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	var doc junitSuites
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("report is not XML: %v\n%s", err, out.String())
+	}
+	if doc.Tests != 1 || doc.Errors != 1 || doc.Failures != 0 || len(doc.Suites) != 1 {
+		t.Fatalf("testsuites: tests=%d errors=%d failures=%d\n%s", doc.Tests, doc.Errors, doc.Failures, out.String())
+	}
+	if c := doc.Suites[0].Cases[0]; c.Name != "submit" || c.Error == nil || c.Error.Message != "not logged in" || c.Failure != nil {
+		t.Fatalf("error case = %+v", c)
+	}
+
+	r = &Report{Format: ReportTAP}
+	r.AddError("run", context.Canceled)
+	out.Reset()
+	if err := r.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if want := "1..1\nnot ok 1 - vleet: run\n  ---\n  message: \"context canceled\"\n"; !strings.Contains(out.String(), want) {
+		t.Fatalf("TAP report:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// A rejected verdict is already in the report, and success adds nothing.
+	r = &Report{Format: ReportTAP}
+	r.AddError("submit", errx.Mark(errors.New("Wrong Answer"), errx.ErrNotAccepted))
+	r.AddError("submit", nil)
+	out.Reset()
+	if err := r.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.String() != "TAP version 13\n1..0\n" {
+		t.Fatalf("TAP report:\n%s", out.String())
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	t.Parallel()
+
+	for spec, want := range map[string][2]string{
+		"junit":            {ReportJUnit, ""},
+		"junit=report.xml": {ReportJUnit, "report.xml"},
+		"tap":              {ReportTAP, ""},
+		"tap=out.tap":      {ReportTAP, "out.tap"},
+	} {
+		r, err := ParseReport(spec)
+		if err != nil || r.Format != want[0] || r.Path != want[1] {
+			t.Fatalf("ParseReport(%q) = %+v, %v", spec, r, err)
+		}
+	}
+	if _, err := ParseReport("xunit=x.xml"); err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,205 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+	"vleet/internal/history"
+	"vleet/internal/lcx"
+)
+
+// SchemaVersion names the --json output format. Every JSON document vleet prints is an
+// Envelope carrying it; the data shapes are the types in this file, not go-leetcode's,
+// so library changes can't silently change the output. The JSON Schema files live in
+// docs/v1/schema/. Breaking changes get a new version.
+const SchemaVersion = "vleet/v1"
+
+// Envelope kinds, one per data shape.
+const (
+	KindQuestion     = "question"
+	KindVerdict      = "verdict"
+	KindRunResult    = "run_result"
+	KindSubmissions  = "submissions"
+	KindLangs        = "langs"
+	KindCheckResults = "check_results"
+	KindWorkspaces   = "workspaces"
+	KindHistory      = "history"
+	KindEvent        = "event"
+	KindError        = "error"
+)
+
+// Envelope wraps every JSON document: {"schema":"vleet/v1","kind":...,"data":...}.
+type Envelope struct {
+	Schema string `json:"schema"`
+	Kind   string `json:"kind"`
+	Data   any    `json:"data"`
+}
+
+func writeEnvelope(w io.Writer, kind string, data any) error {
+	return json.NewEncoder(w).Encode(Envelope{Schema: SchemaVersion, Kind: kind, Data: data})
+}
+
+// Question is the JSON shape of a LeetCode problem.
+type Question struct {
+	QuestionID       string    `json:"question_id"`
+	FrontendID       string    `json:"frontend_id"`
+	Title            string    `json:"title"`
+	TitleSlug        string    `json:"title_slug"`
+	Difficulty       string    `json:"difficulty"`
+	ContentHTML      string    `json:"content_html"`
+	ExampleTestcases string    `json:"example_testcases"`
+	Hints            []string  `json:"hints"`
+	Tags             []Tag     `json:"tags"`
+	CodeSnippets     []Snippet `json:"code_snippets"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type Snippet struct {
+	Lang     string `json:"lang"`
+	LangSlug string `json:"lang_slug"`
+	Code     string `json:"code"`
+}
+
+func NewQuestion(q leetcode.Question) Question {
+	out := Question{
+		QuestionID:       q.QuestionID,
+		FrontendID:       q.FrontendID,
+		Title:            q.Title,
+		TitleSlug:        q.TitleSlug,
+		Difficulty:       q.Difficulty,
+		ContentHTML:      q.ContentHTML,
+		ExampleTestcases: q.ExampleTestcases,
+		Hints:            append([]string{}, q.Hints...),
+		Tags:             make([]Tag, 0, len(q.TopicTags)),
+		CodeSnippets:     make([]Snippet, 0, len(q.CodeSnippets)),
+	}
+	for _, t := range q.TopicTags {
+		out.Tags = append(out.Tags, Tag{Name: t.Name, Slug: t.Slug})
+	}
+	for _, s := range q.CodeSnippets {
+		out.CodeSnippets = append(out.CodeSnippets, Snippet{Lang: s.Lang, LangSlug: s.LangSlug, Code: s.Code})
+	}
+	return out
+}
+
+// Verdict is the JSON shape of a judged submission.
+type Verdict struct {
+	// State is LeetCode's check state (SUCCESS/FAILURE); Status is the verdict
+	// (e.g. "Accepted", "Wrong Answer").
+	State        string `json:"state"`
+	Status       string `json:"status"`
+	Runtime      string `json:"runtime,omitempty"`
+	Memory       string `json:"memory,omitempty"`
+	CompileError string `json:"compile_error,omitempty"`
+	RuntimeError string `json:"runtime_error,omitempty"`
+}
+
+func NewVerdict(r leetcode.SubmissionResult) Verdict {
+	return Verdict{
+		State:        r.State,
+		Status:       r.Status,
+		Runtime:      r.Runtime,
+		Memory:       r.Memory,
+		CompileError: r.CompileError,
+		RuntimeError: r.RuntimeError,
+	}
+}
+
+// RunResult is the JSON shape of a run against custom testcases.
+type RunResult struct {
+	State          string   `json:"state"`
+	Status         string   `json:"status"`
+	Correct        bool     `json:"correct"`
+	CodeAnswer     []string `json:"code_answer"`
+	ExpectedAnswer []string `json:"expected_answer"`
+	StdOutput      []string `json:"std_output"`
+	TotalCorrect   int      `json:"total_correct"`
+	TotalTestcases int      `json:"total_testcases"`
+	Runtime        string   `json:"runtime,omitempty"`
+	Memory         string   `json:"memory,omitempty"`
+	CompileError   string   `json:"compile_error,omitempty"`
+	RuntimeError   string   `json:"runtime_error,omitempty"`
+}
+
+func NewRunResult(r lcx.RunResult) RunResult {
+	return RunResult{
+		State:          r.State,
+		Status:         r.Status,
+		Correct:        r.Correct,
+		CodeAnswer:     nonNil(r.CodeAnswer),
+		ExpectedAnswer: nonNil(r.ExpectedAnswer),
+		StdOutput:      nonNil(r.StdOutput),
+		TotalCorrect:   r.TotalCorrect,
+		TotalTestcases: r.TotalTestcases,
+		Runtime:        r.Runtime,
+		Memory:         r.Memory,
+		CompileError:   r.CompileError,
+		RuntimeError:   r.RuntimeError,
+	}
+}
+
+// Workspace is the JSON shape of a local workspace (vleet serve: GET /v1/workspaces).
+type Workspace struct {
+	Slug string `json:"slug"`
+
+	// Dir is the workspace dir relative to the root.
+	Dir   string   `json:"dir"`
+	Langs []string `json:"langs"`
+}
+
+// HistoryRecord is the JSON shape of a submission recorded in a workspace's history.
+type HistoryRecord struct {
+	Time         time.Time `json:"time"`
+	SubmissionID int64     `json:"submission_id"`
+	ProblemKey   string    `json:"problem_key"`
+	Lang         string    `json:"lang"`
+	File         string    `json:"file,omitempty"`
+	Variant      string    `json:"variant,omitempty"`
+	State        string    `json:"state"`
+	Status       string    `json:"status,omitempty"`
+	Runtime      string    `json:"runtime,omitempty"`
+	Memory       string    `json:"memory,omitempty"`
+}
+
+func NewHistoryRecord(r history.Record) HistoryRecord {
+	return HistoryRecord{
+		Time:         r.Time,
+		SubmissionID: r.SubmissionID,
+		ProblemKey:   r.ProblemKey,
+		Lang:         r.Lang,
+		File:         r.File,
+		Variant:      r.Variant,
+		State:        r.State,
+		Status:       r.Status,
+		Runtime:      r.Runtime,
+		Memory:       r.Memory,
+	}
+}
+
+// ErrorData is the JSON shape of a failed command (printed on stderr) or API request.
+type ErrorData struct {
+	Message string `json:"message"`
+
+	// Code names the error kind (e.g. "auth", "not_found"; see errx) and Hint suggests a
+	// fix; both are omitted for errors without a kind.
+	Code string `json:"code,omitempty"`
+	Hint string `json:"hint,omitempty"`
+}
+
+func NewErrorData(err error) ErrorData {
+	return ErrorData{Message: err.Error(), Code: errx.Code(err), Hint: errx.Hint(err)}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,263 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
+	"vleet/internal/history"
+	"vleet/internal/lcx"
+)
+
+// kSchemaDir holds the published JSON Schema files, one per envelope kind.
+const kSchemaDir = "../../docs/v1/schema"
+
+// validate checks v against the subset of JSON Schema used in kSchemaDir: type,
+// properties, required, additionalProperties: false, items, const and enum.
+func validate(schema map[string]any, v any, path string) error {
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, v) {
+		return fmt.Errorf("%s: got %v, want const %v", path, v, c)
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || reflect.DeepEqual(e, v)
+		}
+		if !found {
+			return fmt.Errorf("%s: %v not in enum %v", path, v, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case nil:
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: got %T, want object", path, v)
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for _, r := range schema["required"].([]any) {
+			if _, ok := obj[r.(string)]; !ok {
+				return fmt.Errorf("%s: missing required %q", path, r)
+			}
+		}
+		for k, fv := range obj {
+			ps, ok := props[k].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: property %q is not in the schema", path, k)
+				}
+				continue
+			}
+			if err := validate(ps, fv, path+"."+k); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: got %T, want array", path, v)
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range arr {
+			if err := validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: got %T, want string", path, v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: got %T, want boolean", path, v)
+		}
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok || strings.ContainsAny(n.String(), ".eE") {
+			return fmt.Errorf("%s: got %v, want integer", path, v)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported schema type %v", path, schema["type"])
+	}
+	return nil
+}
+
+func loadSchema(t *testing.T, kind string) map[string]any {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(kSchemaDir, kind+".json"))
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("parse schema %s: %v", kind, err)
+	}
+	return schema
+}
+
+// checkDocs validates every JSON line in out against the schema of kind.
+func checkDocs(t *testing.T, kind string, out string) {
+	t.Helper()
+	schema := loadSchema(t, kind)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for _, line := range lines {
+		dec := json.NewDecoder(strings.NewReader(line))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			t.Fatalf("%s: output is not JSON: %v\n%s", kind, err, line)
+		}
+		if err := validate(schema, v, kind); err != nil {
+			t.Fatalf("%s: %v\n%s", kind, err, line)
+		}
+	}
+}
+
+func TestJSONOutput_MatchesPublishedSchemas(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		kind  string
+		print func(p *StdPrinter) error
+	}{
+		{KindQuestion, func(p *StdPrinter) error {
+			if err := p.PrintQuestion(ctx, leetcode.Question{}); err != nil {
+				return err
+			}
+			return p.PrintQuestion(ctx, leetcode.Question{
+				QuestionID: "1", FrontendID: "1", Title: "Two Sum", TitleSlug: "two-sum", Difficulty: "Easy",
+				ContentHTML: "<p>x</p>", ExampleTestcases: "[2,7]\n9", SampleTestCase: "[2,7]\n9", Hints: []string{"hash it"},
+				TopicTags:    []leetcode.TopicTag{{Name: "Array", Slug: "array"}},
+				CodeSnippets: []leetcode.CodeSnippet{{Lang: "C++", LangSlug: "cpp", Code: "class Solution {};"}},
+			})
+		}},
+		{KindVerdict, func(p *StdPrinter) error {
+			if err := p.PrintSubmissionResult(ctx, leetcode.SubmissionResult{State: "SUCCESS", Status: "Accepted"}); err != nil {
+				return err
+			}
+			return p.PrintSubmissionResult(ctx, leetcode.SubmissionResult{
+				State: "SUCCESS", Status: "Runtime Error", Runtime: "4 ms", Memory: "10 MB", CompileError: "c", RuntimeError: "r",
+			})
+		}},
+		{KindRunResult, func(p *StdPrinter) error {
+			if err := p.PrintRunResult(ctx, lcx.RunResult{}); err != nil {
+				return err
+			}
+			return p.PrintRunResult(ctx, lcx.RunResult{
+				State: "SUCCESS", Status: "Accepted", Correct: true, CodeAnswer: []string{"[0,1]"}, ExpectedAnswer: []string{"[0,1]"},
+				StdOutput: []string{""}, TotalCorrect: 1, TotalTestcases: 1, Runtime: "0 ms", Memory: "1 MB", CompileError: "c", RuntimeError: "r",
+			})
+		}},
+		{KindSubmissions, func(p *StdPrinter) error {
+			if err := p.PrintSubmissions(ctx, nil); err != nil {
+				return err
+			}
+			return p.PrintSubmissions(ctx, []lcx.Submission{{ID: 42, TitleSlug: "two-sum", Status: "Accepted", Lang: "cpp", Runtime: "4 ms", Memory: "10 MB", Time: when}})
+		}},
+		{KindLangs, func(p *StdPrinter) error {
+			if err := p.PrintLangs(ctx, nil); err != nil {
+				return err
+			}
+			return p.PrintLangs(ctx, []LangStatus{{Lang: "cpp", Files: []string{"solution.cpp"}}, {Lang: "python3", Files: []string{"solution.py"}, LastVerdict: "Accepted", LastTime: when}})
+		}},
+		{KindCheckResults, func(p *StdPrinter) error {
+			if err := p.PrintCheckResults(ctx, nil); err != nil {
+				return err
+			}
+			return p.PrintCheckResults(ctx, []CheckResult{
+				{Slug: "two-sum", Lang: "cpp", Mode: "run", Passed: true, Status: "Accepted", TotalCorrect: 3, TotalTestcases: 3, Runtime: "0 ms"},
+				{Slug: "two-sum", Lang: "python3", Mode: "submit", Status: "Wrong Answer", SubmissionID: 42},
+				{Slug: "add-two-numbers", Lang: "cpp", Mode: "submit", Status: "Error", Error: "leetcode submit: status 429"},
+			})
+		}},
+		{KindWorkspaces, func(p *StdPrinter) error {
+			if err := writeEnvelope(p.Out, KindWorkspaces, []Workspace{}); err != nil {
+				return err
+			}
+			return writeEnvelope(p.Out, KindWorkspaces, []Workspace{{Slug: "two-sum", Dir: "two-sum", Langs: []string{"cpp", "python3"}}})
+		}},
+		{KindHistory, func(p *StdPrinter) error {
+			if err := writeEnvelope(p.Out, KindHistory, []HistoryRecord{}); err != nil {
+				return err
+			}
+			return writeEnvelope(p.Out, KindHistory, []HistoryRecord{
+				NewHistoryRecord(history.Record{Time: when, SubmissionID: 7, ProblemKey: "two-sum", Lang: "cpp", State: history.StatePending}),
+				NewHistoryRecord(history.Record{Time: when, SubmissionID: 8, ProblemKey: "two-sum", Lang: "cpp", File: "solution.dp.cpp", Variant: "dp", State: "SUCCESS", Status: "Accepted", Runtime: "4 ms", Memory: "10 MB"}),
+			})
+		}},
+		{KindEvent, func(p *StdPrinter) error {
+			p.PrintEvent(ctx, Event{Kind: EventSubmitted, SubmissionID: 42})
+			p.PrintEvent(ctx, Event{Kind: EventPolling, SubmissionID: 42, State: "PENDING", Attempt: 1})
+			p.PrintEvent(ctx, Event{Kind: EventResult, SubmissionID: 42, Result: &Verdict{State: "SUCCESS", Status: "Accepted", Runtime: "4 ms"}})
+			p.PrintEvent(ctx, Event{Kind: EventError, Error: "boom"})
+			return nil
+		}},
+	}
+
+	covered := map[string]bool{KindError: true}
+	for _, tc := range cases {
+		var out bytes.Buffer
+		p := NewStdPrinter(&out, &bytes.Buffer{}, true)
+		if err := tc.print(p); err != nil {
+			t.Fatalf("%s: print: %v", tc.kind, err)
+		}
+		checkDocs(t, tc.kind, out.String())
+		covered[tc.kind] = true
+	}
+
+	var errOut bytes.Buffer
+	p := NewStdPrinter(&bytes.Buffer{}, &errOut, true)
+	if err := p.PrintError(ctx, errors.New("boom")); err != nil {
+		t.Fatalf("PrintError: %v", err)
+	}
+	if err := p.PrintError(ctx, errx.Mark(errors.New("no workspace for two-sum"), errx.ErrNotFound)); err != nil {
+		t.Fatalf("PrintError: %v", err)
+	}
+	checkDocs(t, KindError, errOut.String())
+
+	// Every published schema is exercised above.
+	files, err := filepath.Glob(filepath.Join(kSchemaDir, "*.json"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	var uncovered []string
+	for _, f := range files {
+		if kind := strings.TrimSuffix(filepath.Base(f), ".json"); !covered[kind] {
+			uncovered = append(uncovered, kind)
+		}
+	}
+	sort.Strings(uncovered)
+	if len(uncovered) > 0 {
+		t.Fatalf("schemas without a contract test: %v", uncovered)
+	}
+}
+
+func TestJSONOutput_RejectsUnpublishedFields(t *testing.T) {
+	t.Parallel()
+
+	// The validator must catch drift, or the contract test above proves nothing.
+	doc := `{"schema":"vleet/v1","kind":"verdict","data":{"state":"SUCCESS","status":"Accepted","Runtime":"4 ms"}}`
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if err := validate(loadSchema(t, KindVerdict), v, "verdict"); err == nil || !strings.Contains(err.Error(), `"Runtime"`) {
+		t.Fatalf("validate() error = %v, want an unknown-property error", err)
+	}
+}
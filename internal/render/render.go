@@ -47,7 +47,7 @@ func (r *HTMLRenderer) RenderHeader(ctx context.Context, lang string, q leetcode
 	slug := strings.TrimSpace(q.TitleSlug)
 	if slug != "" {
 		// Keep the URL stable and explicit; if we later support regions, this can become configurable.
-		b.WriteString(fmt.Sprintf("URL: https://leetcode.com/problems/%s/\n", slug))
+		b.WriteString(kURLLabel + problemURL(slug) + "\n")
 	}
 
 	if tags := joinTags(q.TopicTags); tags != "" {
@@ -71,6 +71,82 @@ func (r *HTMLRenderer) RenderHeader(ctx context.Context, lang string, q leetcode
 	return prefixLines(prefix, headerBody), nil
 }
 
+// SplitHeader splits a solution file into the vleet-generated header block and the rest.
+//
+// The header is the run of comment lines at the top of the file, terminated by the first
+// empty line (blank lines inside the header are emitted as bare comment markers, see
+// prefixLines). body is returned verbatim, starting at that empty line. ok is false when
+// content does not start with a header block for lang, or when the empty line after it
+// is missing: comments the user wrote right below the header can't be told apart from
+// it then, and must not be taken for part of it.
+func SplitHeader(lang string, content string) (header string, body string, ok bool) {
+	marker := strings.TrimSpace(commentPrefix(lang))
+
+	end := 0
+	separated := true
+	for end < len(content) {
+		nl := strings.IndexByte(content[end:], '\n')
+		line := content[end:]
+		next := len(content)
+		if nl >= 0 {
+			line = content[end : end+nl]
+			next = end + nl + 1
+		}
+		if strings.TrimRight(line, "\r") == "" {
+			break
+		}
+		if !strings.HasPrefix(line, marker) {
+			separated = false
+			break
+		}
+		end = next
+	}
+	if end == 0 || !separated {
+		return "", content, false
+	}
+	return content[:end], content[end:], true
+}
+
+// IsGeneratedHeader reports whether header (as returned by SplitHeader) was rendered by
+// vleet for slug: a title line followed by the problem URL line. A leading comment the
+// user wrote (e.g. a license block) is not.
+func IsGeneratedHeader(lang string, slug string, header string) bool {
+	prefix := commentPrefix(lang)
+	lines := strings.SplitN(header, "\n", 3)
+	if len(lines) < 2 {
+		return false
+	}
+	title := strings.TrimSpace(strings.TrimPrefix(lines[0], prefix))
+	url := strings.TrimRight(lines[1], "\r")
+	return title != "" && url == prefix+kURLLabel+problemURL(strings.TrimSpace(slug))
+}
+
+// BodyStartLine returns the 1-based line of the first non-blank line after the header
+// block (typically the first line of the starter snippet). It returns 1 when content
+// has no header.
+func BodyStartLine(lang string, content string) int {
+	header, body, ok := SplitHeader(lang, content)
+	if !ok {
+		return 1
+	}
+	line := strings.Count(header, "\n") + 1
+	for _, l := range strings.SplitAfter(body, "\n") {
+		if strings.TrimSpace(l) != "" {
+			break
+		}
+		if strings.HasSuffix(l, "\n") {
+			line++
+		}
+	}
+	return line
+}
+
+const kURLLabel = "URL: "
+
+func problemURL(slug string) string {
+	return fmt.Sprintf("https://leetcode.com/problems/%s/", slug)
+}
+
 func commentPrefix(lang string) string {
 	switch strings.ToLower(strings.TrimSpace(lang)) {
 	case "python3":
@@ -98,3 +98,64 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestSplitHeader_PreservesBodyVerbatim(t *testing.T) {
+	t.Parallel()
+
+	header := "# Two Sum (Easy)\n#\n# desc\n"
+	body := "\n# Definition for a node.\nclass Solution:\n    pass\n"
+
+	gotHeader, gotBody, ok := SplitHeader("python3", header+body)
+	if !ok {
+		t.Fatalf("SplitHeader() ok = false, want true")
+	}
+	if gotHeader != header {
+		t.Fatalf("header = %q, want %q", gotHeader, header)
+	}
+	if gotBody != body {
+		t.Fatalf("body = %q, want %q", gotBody, body)
+	}
+	if got := BodyStartLine("python3", header+body); got != 5 {
+		t.Fatalf("BodyStartLine() = %d, want %d", got, 5)
+	}
+}
+
+func TestSplitHeader_NoHeader(t *testing.T) {
+	t.Parallel()
+
+	const content = "class Solution {};\n"
+	if _, body, ok := SplitHeader("cpp", content); ok || body != content {
+		t.Fatalf("SplitHeader() = (%q, %v), want (%q, false)", body, ok, content)
+	}
+	if got := BodyStartLine("cpp", content); got != 1 {
+		t.Fatalf("BodyStartLine() = %d, want 1", got)
+	}
+}
+
+func TestSplitHeader_NoSeparator(t *testing.T) {
+	t.Parallel()
+
+	// The blank line after the header was deleted; the user's comment follows it directly.
+	content := "// Two Sum (Easy)\n// URL: https://leetcode.com/problems/two-sum/\n// my notes\nclass Solution {};\n"
+	if _, body, ok := SplitHeader("cpp", content); ok || body != content {
+		t.Fatalf("SplitHeader() = (%q, %v), want (%q, false)", body, ok, content)
+	}
+}
+
+func TestIsGeneratedHeader(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHTMLRenderer().RenderHeader(context.Background(), "python3", leetcode.Question{Title: "Two Sum", TitleSlug: "two-sum"})
+	if err != nil {
+		t.Fatalf("RenderHeader() error = %v", err)
+	}
+	if !IsGeneratedHeader("python3", "two-sum", h+"\n") {
+		t.Fatalf("rendered header not recognized:\n%s", h)
+	}
+	if IsGeneratedHeader("python3", "add-two-numbers", h+"\n") {
+		t.Fatalf("header recognized for another problem")
+	}
+	if IsGeneratedHeader("python3", "two-sum", "# Copyright 2026 me\n# notes\n") {
+		t.Fatalf("hand-written comment recognized as generated")
+	}
+}
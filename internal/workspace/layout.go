@@ -0,0 +1,215 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+const (
+	// DefaultLayout puts each workspace directly under the root, named after its slug.
+	DefaultLayout = "{{.Slug}}"
+
+	kRegistryFileName = "workspaces.json"
+
+	// kMaxFindDepth bounds the directory scan in ListWorkspaces.
+	kMaxFindDepth = 4
+)
+
+// LayoutData is the data available to workspace.layout templates, e.g.
+// "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}" or "{{index .Tags 0}}/{{.Slug}}".
+type LayoutData struct {
+	Slug       string
+	Title      string
+	FrontendID string
+	QuestionID string
+	Difficulty string
+
+	// Tags are topic tag slugs (e.g. "hash-table"); TagNames are the display names.
+	Tags     []string
+	TagNames []string
+}
+
+// LayoutDir renders layout for q and returns the workspace dir relative to the root.
+// The result must stay inside the root.
+func LayoutDir(layout string, q leetcode.Question) (string, error) {
+	layout = strings.TrimSpace(layout)
+	if layout == "" {
+		layout = DefaultLayout
+	}
+
+	tmpl, err := template.New("layout").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return "", fmt.Errorf("parse workspace.layout %q: %w", layout, err)
+	}
+
+	data := LayoutData{
+		Slug:       strings.TrimSpace(q.TitleSlug),
+		Title:      strings.TrimSpace(q.Title),
+		FrontendID: strings.TrimSpace(q.FrontendID),
+		QuestionID: strings.TrimSpace(q.QuestionID),
+		Difficulty: strings.TrimSpace(q.Difficulty),
+	}
+	for _, t := range q.TopicTags {
+		data.Tags = append(data.Tags, t.Slug)
+		data.TagNames = append(data.TagNames, t.Name)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render workspace.layout %q for %s: %w", layout, data.Slug, err)
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimSpace(b.String())))
+	if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("workspace.layout %q rendered an invalid dir %q for %s", layout, b.String(), data.Slug)
+	}
+	return rel, nil
+}
+
+// Registry returns the slug -> workspace dir (relative to root) map recorded for
+// workspaces created with a non-default layout. A missing registry is empty.
+func Registry(root string) (map[string]string, error) {
+	p := registryPath(root)
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("read workspace registry %s: %w", p, err)
+	}
+	reg := map[string]string{}
+	if err := json.Unmarshal(b, &reg); err != nil {
+		return nil, fmt.Errorf("parse workspace registry %s: %w", p, err)
+	}
+	return reg, nil
+}
+
+func registryPath(root string) string {
+	return filepath.Join(root, ".vleet", kRegistryFileName)
+}
+
+func (m *FSManager) register(root string, slug string, rel string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reg, err := Registry(root)
+	if err != nil {
+		return err
+	}
+	if reg[slug] == filepath.ToSlash(rel) {
+		return nil
+	}
+	reg[slug] = filepath.ToSlash(rel)
+
+	p := registryPath(root)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("create workspace registry dir %s: %w", filepath.Dir(p), err)
+	}
+	b, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode workspace registry: %w", err)
+	}
+	if err := os.WriteFile(p, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write workspace registry %s: %w", p, err)
+	}
+	return nil
+}
+
+// FindDir locates the workspace for slug under root: <root>/<slug>, then the registry.
+// Other dirs are never guessed from their names: a dir ending in the slug may belong to
+// another problem (e.g. 64-minimum-path-sum for path-sum).
+func FindDir(root string, slug string) (string, bool) {
+	direct := filepath.Join(root, slug)
+	if fi, err := os.Stat(direct); err == nil && fi.IsDir() {
+		return direct, true
+	}
+
+	reg, err := Registry(root)
+	if err != nil {
+		return "", false
+	}
+	rel, ok := reg[slug]
+	if !ok {
+		return "", false
+	}
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	if fi, err := os.Stat(p); err == nil && fi.IsDir() {
+		return p, true
+	}
+	return "", false
+}
+
+// Located is a workspace found under a root by ListWorkspaces.
+type Located struct {
+	Slug string
+
+	// Dir is the workspace dir relative to the root.
+	Dir string
+}
+
+// ListWorkspaces finds the workspaces (dirs containing solution.<ext> files) under root,
+// following any layout depth up to a small limit. Hidden dirs are skipped. The slug comes
+// from the registry when the dir was created with a custom layout; an unregistered dir
+// is a workspace only at <root>/<slug> (the default layout), so its name is the slug.
+// Other unregistered dirs are skipped rather than guessed from their names.
+func ListWorkspaces(root string) ([]Located, error) {
+	reg, err := Registry(root)
+	if err != nil {
+		return nil, err
+	}
+	slugByDir := make(map[string]string, len(reg))
+	for slug, rel := range reg {
+		slugByDir[rel] = slug
+	}
+
+	var out []Located
+	rootDepth := strings.Count(filepath.Clean(root), string(filepath.Separator))
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() || p == root {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if strings.Count(filepath.Clean(p), string(filepath.Separator))-rootDepth > kMaxFindDepth {
+			return filepath.SkipDir
+		}
+
+		files, err := SolutionFiles(p)
+		if err != nil || len(files) == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		slug, ok := slugByDir[rel]
+		if !ok {
+			if strings.Contains(rel, "/") {
+				return filepath.SkipDir
+			}
+			slug = d.Name()
+		}
+		out = append(out, Located{Slug: slug, Dir: rel})
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan workspaces in %s: %w", root, err)
+	}
+	return out, nil
+}
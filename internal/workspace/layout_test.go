@@ -0,0 +1,125 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/therootusr/go-leetcode"
+)
+
+func TestLayoutDir(t *testing.T) {
+	t.Parallel()
+
+	q := leetcode.Question{
+		TitleSlug:  "two-sum",
+		FrontendID: "1",
+		Difficulty: "Easy",
+		TopicTags:  []leetcode.TopicTag{{Name: "Hash Table", Slug: "hash-table"}},
+	}
+	cases := []struct {
+		layout  string
+		want    string
+		wantErr string
+	}{
+		{layout: "", want: "two-sum"},
+		{layout: "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}", want: filepath.Join("Easy", "1-two-sum")},
+		{layout: "{{index .Tags 0}}/{{.Slug}}", want: filepath.Join("hash-table", "two-sum")},
+		{layout: "{{.Nope}}", wantErr: "render workspace.layout"},
+		{layout: "../{{.Slug}}", wantErr: "invalid dir"},
+		{layout: "{{.Slug", wantErr: "parse workspace.layout"},
+	}
+	for _, tc := range cases {
+		got, err := LayoutDir(tc.layout, q)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("LayoutDir(%q) error = %v, want %q", tc.layout, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("LayoutDir(%q) = (%q, %v), want %q", tc.layout, got, err, tc.want)
+		}
+	}
+
+	if _, err := LayoutDir("{{index .Tags 0}}/{{.Slug}}", leetcode.Question{TitleSlug: "no-tags"}); err == nil {
+		t.Errorf("LayoutDir() with no tags should fail")
+	}
+}
+
+func TestFSManager_Layout_LoadBySlug(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	m := NewFSManager()
+	ctx := context.Background()
+	q := leetcode.Question{TitleSlug: "two-sum", FrontendID: "1", Difficulty: "Easy"}
+
+	ws, err := m.CreateWorkspace(ctx, root, q, "cpp", CreateOptions{Layout: "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}"})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	if want := filepath.Join(root, "Easy", "1-two-sum"); ws.Dir != want {
+		t.Fatalf("ws.Dir = %q, want %q", ws.Dir, want)
+	}
+	if err := m.WriteSolution(ctx, ws, "x\n"); err != nil {
+		t.Fatalf("WriteSolution() error = %v", err)
+	}
+
+	got, err := m.LoadWorkspace(ctx, root, "two-sum", "cpp", "")
+	if err != nil {
+		t.Fatalf("LoadWorkspace() error = %v", err)
+	}
+	if got.Dir != ws.Dir || got.SolutionPath != ws.SolutionPath || got.ProblemKey != "two-sum" {
+		t.Fatalf("LoadWorkspace() = %+v, want %+v", got, ws)
+	}
+
+	located, err := ListWorkspaces(root)
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error = %v", err)
+	}
+	if want := []Located{{Slug: "two-sum", Dir: "Easy/1-two-sum"}}; !reflect.DeepEqual(located, want) {
+		t.Fatalf("ListWorkspaces() = %+v, want %+v", located, want)
+	}
+}
+
+func TestFindDir_DoesNotGuessFromDirNames(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	m := NewFSManager()
+	ctx := context.Background()
+	q := leetcode.Question{TitleSlug: "minimum-path-sum", FrontendID: "64", Difficulty: "Medium"}
+	ws, err := m.CreateWorkspace(ctx, root, q, "cpp", CreateOptions{Layout: "{{.Difficulty}}/{{.FrontendID}}-{{.Slug}}"})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	if err := m.WriteSolution(ctx, ws, "x\n"); err != nil {
+		t.Fatalf("WriteSolution() error = %v", err)
+	}
+	// A dir created by hand, not in the registry.
+	if err := os.MkdirAll(filepath.Join(root, "Easy", "112-path-sum"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "Easy", "112-path-sum", "solution.cpp"), []byte("y\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if dir, ok := FindDir(root, "path-sum"); ok {
+		t.Fatalf("FindDir(path-sum) = %q, want not found", dir)
+	}
+	if ws, err := m.LoadWorkspace(ctx, root, "path-sum", "cpp", ""); err == nil {
+		t.Fatalf("LoadWorkspace(path-sum) = %+v, want an error", ws)
+	}
+
+	located, err := ListWorkspaces(root)
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error = %v", err)
+	}
+	if want := []Located{{Slug: "minimum-path-sum", Dir: "Medium/64-minimum-path-sum"}}; !reflect.DeepEqual(located, want) {
+		t.Fatalf("ListWorkspaces() = %+v, want %+v", located, want)
+	}
+}
@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+const (
+	kDefaultWatchInterval = 200 * time.Millisecond
+	kDefaultWatchDebounce = 300 * time.Millisecond
+)
+
+// WatchOptions configures WatchFile.
+type WatchOptions struct {
+	// Interval is how often the file is stat'ed (default: 200ms).
+	Interval time.Duration
+
+	// Debounce is how long the file must stay unchanged before a change is reported
+	// (default: 300ms). Editors often write in several steps; this coalesces them.
+	Debounce time.Duration
+}
+
+// WatchFile reports settled changes to path (mtime or size) on the returned channel.
+// Polling keeps this dependency-free and works the same for editors that write in place
+// and editors that write a temp file and rename it. The channel is closed when ctx ends.
+//
+// At most one change is buffered: a slow consumer sees one event for several saves.
+func WatchFile(ctx context.Context, path string, opts WatchOptions) <-chan struct{} {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = kDefaultWatchInterval
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = kDefaultWatchDebounce
+	}
+
+	ch := make(chan struct{}, 1)
+	go func() {
+		defer close(ch)
+
+		last := statSignature(path)
+		pending := false
+		var changedAt time.Time
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				sig := statSignature(path)
+				if sig != last {
+					last = sig
+					pending = true
+					changedAt = now
+					continue
+				}
+				// A missing file is usually mid-rename; wait for it to reappear.
+				if pending && sig.exists && now.Sub(changedAt) >= debounce {
+					pending = false
+					select {
+					case ch <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+type fileSignature struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func statSignature(path string) fileSignature {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileSignature{}
+	}
+	return fileSignature{modTime: fi.ModTime(), size: fi.Size(), exists: true}
+}
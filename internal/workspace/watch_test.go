@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFile_DebouncesBurstOfWrites(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "solution.cpp")
+	if err := os.WriteFile(path, []byte("v0"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := WatchFile(ctx, path, WatchOptions{Interval: 5 * time.Millisecond, Debounce: 60 * time.Millisecond})
+
+	// A burst of writes (different sizes so the change is visible regardless of mtime
+	// granularity) should produce a single event once the file settles.
+	for i := 1; i <= 3; i++ {
+		if err := os.WriteFile(path, []byte(string(make([]byte, i*10))), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for change event")
+	}
+
+	select {
+	case <-changes:
+		t.Fatalf("got a second event for a single burst of writes")
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Fatalf("expected channel to be closed after cancel")
+	}
+}
@@ -6,15 +6,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/therootusr/go-leetcode"
+	"vleet/internal/errx"
 )
 
 const (
 	kDefaultSolutionBaseName = "solution"
+
+	// kAttemptsDirName holds solutions archived by ArchiveSolution (vleet reset).
+	kAttemptsDirName = "attempts"
 )
 
+var kVariantNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
 // Workspace represents the per-problem directory described in docs/design.md.
 type Workspace struct {
 	// Dir is the workspace directory (typically ./<problem-key>/).
@@ -28,11 +37,19 @@ type Workspace struct {
 
 	// SolutionPath is the resolved path to solution.<ext> inside Dir (unless overridden).
 	SolutionPath string
+
+	// Variant is the solution variant name for solution.<variant>.<ext> files ("" for the
+	// default solution.<ext> or a custom file name).
+	Variant string
 }
 
 type CreateOptions struct {
 	// File overrides the default solution file name/path (must match language extension).
 	File string
+
+	// Layout is a text/template for the workspace dir relative to root
+	// (default: DefaultLayout). See LayoutData for the available fields.
+	Layout string
 }
 
 // Manager is the internal API contract described in docs/architecture.md.
@@ -41,10 +58,20 @@ type Manager interface {
 	LoadWorkspace(ctx context.Context, dir string, problemKey string, lang string, file string) (Workspace, error)
 	ReadSolution(ctx context.Context, ws Workspace) (string, error)
 	WriteSolution(ctx context.Context, ws Workspace, content string) error
+
+	// ReplaceSolution overwrites the solution file. Callers must only use it on explicit
+	// user request (e.g. --force); WriteSolution is the default.
+	ReplaceSolution(ctx context.Context, ws Workspace, content string) error
+
+	// ArchiveSolution moves the solution file into <workspace>/attempts/ and returns its
+	// new path, leaving the workspace without a solution file.
+	ArchiveSolution(ctx context.Context, ws Workspace) (string, error)
 }
 
 // FSManager manages workspaces on disk.
-type FSManager struct{}
+type FSManager struct {
+	mu sync.Mutex // guards the workspace registry
+}
 
 func NewFSManager() *FSManager { return &FSManager{} }
 
@@ -63,11 +90,15 @@ func (m *FSManager) CreateWorkspace(ctx context.Context, root string, q leetcode
 		return Workspace{}, fmt.Errorf("question titleSlug is required")
 	}
 
-	workspaceDir := strings.TrimSpace(root)
-	if workspaceDir == "" {
-		workspaceDir = "."
+	root = strings.TrimSpace(root)
+	if root == "" {
+		root = "."
 	}
-	workspaceDir = filepath.Join(workspaceDir, problemKey)
+	rel, err := LayoutDir(opts.Layout, q)
+	if err != nil {
+		return Workspace{}, err
+	}
+	workspaceDir := filepath.Join(root, rel)
 
 	ext, err := extensionForLang(lang)
 	if err != nil {
@@ -92,11 +123,19 @@ func (m *FSManager) CreateWorkspace(ctx context.Context, root string, q leetcode
 		return Workspace{}, fmt.Errorf("stat solution %s: %w", solutionPath, err)
 	}
 
+	// Workspaces at <root>/<slug> are found without the registry.
+	if rel != problemKey {
+		if err := m.register(root, problemKey, rel); err != nil {
+			return Workspace{}, err
+		}
+	}
+
 	return Workspace{
 		Dir:          workspaceDir,
 		ProblemKey:   problemKey,
 		Lang:         lang,
 		SolutionPath: solutionPath,
+		Variant:      VariantOf(solutionPath),
 	}, nil
 }
 
@@ -116,7 +155,11 @@ func (m *FSManager) LoadWorkspace(ctx context.Context, dir string, problemKey st
 	var workspaceDir string
 	switch {
 	case dir != "" && problemKey != "":
-		workspaceDir = filepath.Join(dir, problemKey)
+		// dir is the root; the workspace may be nested according to workspace.layout.
+		var ok bool
+		if workspaceDir, ok = FindDir(dir, problemKey); !ok {
+			workspaceDir = filepath.Join(dir, problemKey)
+		}
 	case dir != "" && problemKey == "":
 		workspaceDir = dir
 		problemKey = filepath.Base(strings.TrimRight(workspaceDir, string(filepath.Separator)))
@@ -149,6 +192,7 @@ func (m *FSManager) LoadWorkspace(ctx context.Context, dir string, problemKey st
 		ProblemKey:   problemKey,
 		Lang:         lang,
 		SolutionPath: solutionPath,
+		Variant:      VariantOf(solutionPath),
 	}, nil
 }
 
@@ -194,21 +238,191 @@ func (m *FSManager) WriteSolution(ctx context.Context, ws Workspace, content str
 	return nil
 }
 
+// ReplaceSolution writes content to a temp file next to the solution and renames it over
+// the solution, so an interrupted write never leaves a truncated file behind.
+func (m *FSManager) ReplaceSolution(ctx context.Context, ws Workspace, content string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(ws.SolutionPath) == "" {
+		return fmt.Errorf("workspace solution path is empty")
+	}
+
+	dir := filepath.Dir(ws.SolutionPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create solution dir %s: %w", dir, err)
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(ws.SolutionPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp solution in %s: %w", dir, err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // no-op after a successful rename
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return fmt.Errorf("write solution %s: %w", ws.SolutionPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write solution %s: %w", ws.SolutionPath, err)
+	}
+	if err := os.Chmod(tmp, 0o644); err != nil {
+		return fmt.Errorf("chmod solution %s: %w", ws.SolutionPath, err)
+	}
+	if err := os.Rename(tmp, ws.SolutionPath); err != nil {
+		return fmt.Errorf("replace solution %s: %w", ws.SolutionPath, err)
+	}
+	return nil
+}
+
+func (m *FSManager) ArchiveSolution(ctx context.Context, ws Workspace) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(ws.SolutionPath) == "" {
+		return "", fmt.Errorf("workspace solution path is empty")
+	}
+	if _, err := os.Stat(ws.SolutionPath); err != nil {
+		return "", fmt.Errorf("archive solution %s: %w", ws.SolutionPath, err)
+	}
+
+	dir := filepath.Join(filepath.Dir(ws.SolutionPath), kAttemptsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create attempts dir %s: %w", dir, err)
+	}
+
+	// solution.dp.cpp -> attempts/solution.dp.20260102-150405.cpp (keeps the extension
+	// for editors); a numeric suffix avoids clobbering archives from the same second.
+	base := filepath.Base(ws.SolutionPath)
+	ext := filepath.Ext(base)
+	stamp := time.Now().UTC().Format("20060102-150405")
+	dst := filepath.Join(dir, fmt.Sprintf("%s.%s%s", strings.TrimSuffix(base, ext), stamp, ext))
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(dst); errorsIsNotExist(err) {
+			break
+		}
+		dst = filepath.Join(dir, fmt.Sprintf("%s.%s-%d%s", strings.TrimSuffix(base, ext), stamp, i, ext))
+	}
+
+	if err := os.Rename(ws.SolutionPath, dst); err != nil {
+		return "", fmt.Errorf("archive solution %s: %w", ws.SolutionPath, err)
+	}
+	return dst, nil
+}
+
+// IsSupportedLang reports whether lang has a known solution file extension.
+func IsSupportedLang(lang string) bool {
+	_, err := extensionForLang(lang)
+	return err == nil
+}
+
+// kLangExtensions maps LeetCode language slugs to solution file extensions.
+var kLangExtensions = map[string]string{
+	"cpp":        ".cpp",
+	"golang":     ".go",
+	"python3":    ".py",
+	"javascript": ".js",
+	"typescript": ".ts",
+}
+
 func extensionForLang(lang string) (string, error) {
-	switch strings.ToLower(strings.TrimSpace(lang)) {
-	case "cpp":
-		return ".cpp", nil
-	case "golang":
-		return ".go", nil
-	case "python3":
-		return ".py", nil
-	case "javascript":
-		return ".js", nil
-	case "typescript":
-		return ".ts", nil
-	default:
-		return "", fmt.Errorf("unsupported language slug: %q", lang)
+	ext, ok := kLangExtensions[strings.ToLower(strings.TrimSpace(lang))]
+	if !ok {
+		return "", errx.Mark(fmt.Errorf("unsupported language slug: %q", lang), errx.ErrUnsupportedLang)
+	}
+	return ext, nil
+}
+
+// LangForExtension maps a solution file extension (e.g. ".cpp") back to its language slug.
+func LangForExtension(ext string) (string, bool) {
+	for lang, e := range kLangExtensions {
+		if e == ext {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// VariantFile returns the solution file name for a named variant of lang, e.g.
+// VariantFile("cpp", "dp") == "solution.dp.cpp". An empty variant is the default solution.
+func VariantFile(lang string, variant string) (string, error) {
+	ext, err := extensionForLang(lang)
+	if err != nil {
+		return "", err
+	}
+	variant = strings.TrimSpace(variant)
+	if variant == "" {
+		return kDefaultSolutionBaseName + ext, nil
+	}
+	if !kVariantNameRE.MatchString(variant) {
+		return "", fmt.Errorf("invalid variant name %q (use letters, digits, '-' or '_')", variant)
+	}
+	return kDefaultSolutionBaseName + "." + variant + ext, nil
+}
+
+// VariantOf returns the variant name of a solution file path: "dp" for solution.dp.cpp,
+// "" for solution.cpp or any other file name.
+func VariantOf(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	variant, ok := strings.CutPrefix(name, kDefaultSolutionBaseName+".")
+	if !ok || !kVariantNameRE.MatchString(variant) {
+		return ""
+	}
+	return variant
+}
+
+// SolutionFiles returns the solution files in a workspace dir keyed by language slug:
+// solution.<ext>, or the first solution.<variant>.<ext> when a language only has
+// variants. A missing dir yields an empty map.
+func SolutionFiles(dir string) (map[string]string, error) {
+	all, err := LangFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(all))
+	for lang, files := range all {
+		out[lang] = files[0]
+	}
+	return out, nil
+}
+
+// LangFiles returns every solution file in a workspace dir keyed by language slug:
+// solution.<ext> first, then its variants sorted by name. A missing dir yields an
+// empty map.
+func LangFiles(dir string) (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errorsIsNotExist(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("read workspace dir %s: %w", dir, err)
+	}
+
+	out := make(map[string][]string)
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		ext := filepath.Ext(e.Name())
+		base := strings.TrimSuffix(e.Name(), ext)
+		if base != kDefaultSolutionBaseName && VariantOf(e.Name()) == "" {
+			continue
+		}
+		lang, ok := LangForExtension(ext)
+		if !ok {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		// ReadDir is sorted, so variants are already in order; the default goes first.
+		if base == kDefaultSolutionBaseName {
+			out[lang] = append([]string{p}, out[lang]...)
+		} else {
+			out[lang] = append(out[lang], p)
+		}
 	}
+	return out, nil
 }
 
 func resolveSolutionPath(workspaceDir string, expectedExt string, fileOverride string) (string, error) {
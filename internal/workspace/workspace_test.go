@@ -101,3 +101,86 @@ func TestFSManager_CreateWorkspace_FileOverride_ExtensionMismatch(t *testing.T)
 		t.Fatalf("error = %q, want message to include %q", err.Error(), "does not match language extension")
 	}
 }
+
+func TestFSManager_ReplaceSolution_Overwrites(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	m := NewFSManager()
+	ws, err := m.CreateWorkspace(context.Background(), root, leetcode.Question{TitleSlug: "two-sum"}, "cpp", CreateOptions{})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	if err := m.WriteSolution(context.Background(), ws, "OLD\n"); err != nil {
+		t.Fatalf("WriteSolution() error = %v", err)
+	}
+
+	if err := m.ReplaceSolution(context.Background(), ws, "NEW\n"); err != nil {
+		t.Fatalf("ReplaceSolution() error = %v", err)
+	}
+	got, err := m.ReadSolution(context.Background(), ws)
+	if err != nil || got != "NEW\n" {
+		t.Fatalf("ReadSolution() = (%q, %v), want NEW", got, err)
+	}
+
+	entries, err := os.ReadDir(ws.Dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the solution file (no temp leftovers), got %d entries", len(entries))
+	}
+}
+
+func TestFSManager_Variants(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	m := NewFSManager()
+	q := leetcode.Question{TitleSlug: "two-sum"}
+
+	file, err := VariantFile("cpp", "dp")
+	if err != nil || file != "solution.dp.cpp" {
+		t.Fatalf("VariantFile() = (%q, %v), want solution.dp.cpp", file, err)
+	}
+	if _, err := VariantFile("cpp", "../x"); err == nil {
+		t.Fatalf("VariantFile() accepted an invalid name")
+	}
+
+	ws, err := m.CreateWorkspace(context.Background(), root, q, "cpp", CreateOptions{File: file})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	if ws.Variant != "dp" || filepath.Base(ws.SolutionPath) != "solution.dp.cpp" {
+		t.Fatalf("workspace = %+v, want variant dp", ws)
+	}
+	if err := m.WriteSolution(context.Background(), ws, "DP\n"); err != nil {
+		t.Fatalf("WriteSolution() error = %v", err)
+	}
+
+	// A variant-only workspace still counts as a workspace.
+	files, err := SolutionFiles(ws.Dir)
+	if err != nil || files["cpp"] != ws.SolutionPath {
+		t.Fatalf("SolutionFiles() = (%v, %v), want the dp variant", files, err)
+	}
+
+	// The default solution can be created alongside, and wins in SolutionFiles.
+	def, err := m.CreateWorkspace(context.Background(), root, q, "cpp", CreateOptions{})
+	if err != nil {
+		t.Fatalf("CreateWorkspace(default) error = %v", err)
+	}
+	if err := m.WriteSolution(context.Background(), def, "DEFAULT\n"); err != nil {
+		t.Fatalf("WriteSolution(default) error = %v", err)
+	}
+	if files, _ := SolutionFiles(ws.Dir); files["cpp"] != def.SolutionPath {
+		t.Fatalf("SolutionFiles() = %v, want the default solution", files)
+	}
+
+	loaded, err := m.LoadWorkspace(context.Background(), root, "two-sum", "cpp", file)
+	if err != nil || loaded.Variant != "dp" {
+		t.Fatalf("LoadWorkspace() = (%+v, %v), want variant dp", loaded, err)
+	}
+	if got, _ := m.ReadSolution(context.Background(), loaded); got != "DP\n" {
+		t.Fatalf("ReadSolution() = %q, want DP", got)
+	}
+}